@@ -24,6 +24,15 @@ func run() int {
 	fileFlag := flag.String("file", "", "path to file to scan")
 	configFlag := flag.String("config", "", "path to config YAML file")
 	jsonFlag := flag.Bool("json", false, "output structured JSON")
+	clusterTokensFlag := flag.Bool("cluster-tokens", false, "render entities linked to a person as [PERSON_1_EMAIL]")
+	transcriptFlag := flag.Bool("transcript", false, "treat input as a speech-to-text transcript (spoken numbers and dates)")
+	rolePolicyFlag := flag.String("role-policy", "", "per-role person policy, e.g. patient=redact,clinician=keep (overrides config)")
+	amountsFlag := flag.String("amounts", "", "redact amounts as token, range or magnitude (overrides config)")
+	addressesFlag := flag.String("addresses", "", "redact addresses as token, city or country (overrides config)")
+	urlsFlag := flag.String("urls", "", "redact URLs as token or components (overrides config)")
+	reportFlag := flag.Bool("report", false, "print the document risk report instead of the redacted text")
+	strategiesFlag := flag.String("strategies", "", "per-type strategy, e.g. CREDIT_CARD=partial,EMAIL=type,PERSON=pseudonym (overrides config)")
+	riskThresholdFlag := flag.Float64("risk-threshold", 0, "redact quasi-identifiers when the re-identification risk reaches this value, 0–1 (overrides config)")
 	flag.Parse()
 
 	// Read input text.
@@ -56,17 +65,130 @@ func run() int {
 		allowlist = append(allowlist, re)
 	}
 
+	policy, err := rolePolicy(cfg.Redaction.RolePolicy, *rolePolicyFlag)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: role policy: %v\n", err)
+		return 2
+	}
+	byType, err := strategies(cfg.Redaction, *strategiesFlag)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: strategies: %v\n", err)
+		return 2
+	}
+	amounts := cfg.Redaction.Amounts
+	if *amountsFlag != "" {
+		amounts = *amountsFlag
+	}
+	amountMode, err := redactor.ParseAmountMode(amounts)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		return 2
+	}
+	addresses := cfg.Redaction.Addresses
+	if *addressesFlag != "" {
+		addresses = *addressesFlag
+	}
+	addressMode, err := redactor.ParseAddressMode(addresses)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		return 2
+	}
+	urls := cfg.Redaction.URLs
+	if *urlsFlag != "" {
+		urls = *urlsFlag
+	}
+	urlMode, err := redactor.ParseURLMode(urls)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		return 2
+	}
+	riskThreshold := cfg.Redaction.RiskThreshold
+	if *riskThresholdFlag != 0 {
+		riskThreshold = *riskThresholdFlag
+	}
+	if err := redactor.CheckRiskThreshold(riskThreshold); err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		return 2
+	}
+
 	// Scan.
 	s := scanner.DefaultScanner(allowlist)
+	if *transcriptFlag {
+		s = s.TranscriptMode()
+	}
+	if cfg.Scanner.TestData == "drop" {
+		s = s.DropTestData()
+	}
 	entities := s.Scan(text)
 
+	if *reportFlag {
+		report := scanner.NewReport(text, entities, scanner.WithReportWeights(cfg.Report.Weights))
+		if *jsonFlag {
+			return outputReportJSON(report, len(entities))
+		}
+		return outputReport(report, len(entities), isTerminal())
+	}
+
 	// Redact.
-	result := redactor.Redact(text, entities)
+	result := redactor.Redact(text, entities,
+		redactor.WithClusterTokens(*clusterTokensFlag),
+		redactor.WithRolePolicy(policy),
+		redactor.WithAmountGeneralization(amountMode),
+		redactor.WithAddressGeneralization(addressMode),
+		redactor.WithURLRedaction(urlMode),
+		redactor.WithRiskThreshold(riskThreshold),
+		redactor.WithStrategies(byType),
+	)
 
 	if *jsonFlag {
 		return outputJSON(result)
 	}
-	return outputPretty(result, isTerminal())
+	risk := result.Risk
+	if risk == nil {
+		r := scanner.AssessRisk(text, entities)
+		risk = &r
+	}
+	return outputPretty(result, *risk, isTerminal())
+}
+
+// rolePolicy merges the role=action pairs of the --role-policy flag over the
+// configured policy.
+func rolePolicy(configured map[string]string, flagValue string) (map[string]redactor.Action, error) {
+	merged, err := mergePairs(configured, flagValue, "role=action")
+	if err != nil {
+		return nil, err
+	}
+	return redactor.ParseRolePolicy(merged)
+}
+
+// strategies merges the TYPE=strategy pairs of the --strategies flag over
+// the configured strategies.
+func strategies(redaction config.RedactionConfig, flagValue string) (map[string]redactor.Strategy, error) {
+	overrides, err := mergePairs(nil, flagValue, "TYPE=strategy")
+	if err != nil {
+		return nil, err
+	}
+	return redaction.ParseStrategies(overrides)
+}
+
+// mergePairs merges the comma-separated key=value pairs of a flag over the
+// configured map.
+func mergePairs(configured map[string]string, flagValue, form string) (map[string]string, error) {
+	merged := make(map[string]string, len(configured))
+	for k, v := range configured {
+		merged[k] = v
+	}
+	for _, pair := range strings.Split(flagValue, ",") {
+		if pair = strings.TrimSpace(pair); pair == "" {
+			continue
+		}
+		k, v, ok := strings.Cut(pair, "=")
+		if !ok {
+			return nil, fmt.Errorf("%q is not %s", pair, form)
+		}
+		merged[strings.TrimSpace(k)] = strings.TrimSpace(v)
+	}
+	return merged, nil
 }
 
 func readInput(textFlag, fileFlag string) (string, error) {
@@ -117,6 +239,21 @@ func outputJSON(result redactor.RedactResult) int {
 	return 0
 }
 
+// outputReportJSON prints report as JSON. Like outputJSON it exits with 1
+// when entities were found.
+func outputReportJSON(report scanner.Report, entityCount int) int {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(report); err != nil {
+		fmt.Fprintf(os.Stderr, "error encoding JSON: %v\n", err)
+		return 2
+	}
+	if entityCount > 0 {
+		return 1
+	}
+	return 0
+}
+
 // ANSI color codes.
 const (
 	colorReset   = "\033[0m"
@@ -142,14 +279,14 @@ func entityColor(entityType string) string {
 		return colorCyan
 	case "SECRET", "FINANCIAL", "CREDIT_CARD":
 		return colorRed
-	case "ADDRESS", "IBAN":
+	case "ADDRESS", "IBAN", "BANK_ACCOUNT":
 		return colorGreen
 	default:
 		return colorYellow
 	}
 }
 
-func outputPretty(result redactor.RedactResult, useColor bool) int {
+func outputPretty(result redactor.RedactResult, risk scanner.Risk, useColor bool) int {
 	entityCount := len(result.Entities)
 
 	// --- ORIGINAL section with highlighted entities ---
@@ -210,8 +347,74 @@ func outputPretty(result redactor.RedactResult, useColor bool) int {
 				fmt.Printf("  %-14s %d\n", t, typeCounts[t])
 			}
 		}
+
+		// Individuals and the entities linked to them.
+		if len(result.Clusters) > 0 {
+			fmt.Printf("\nIndividuals: %d\n\n", len(result.Clusters))
+			for _, c := range result.Clusters {
+				fmt.Printf("  %-3d %s%s\n", c.ID, c.Name, linkedTypes(c))
+			}
+		}
+
+		// Re-identification risk and the combinations behind it.
+		fmt.Printf("\nRe-identification risk: %.0f%% (%s)\n", risk.Score*100, risk.Level)
+		if risk.Score == 0 {
+			fmt.Printf("  %s\n", risk.Explanation)
+		}
+		for _, c := range risk.Combinations {
+			if c.Score > 0 {
+				fmt.Printf("  %s\n", c.Explanation)
+			}
+		}
+	}
+
+	fmt.Println()
+
+	if entityCount > 0 {
+		return 1
+	}
+	return 0
+}
+
+// verdictColor returns the color of a report verdict.
+func verdictColor(verdict string) string {
+	switch verdict {
+	case scanner.VerdictCritical, scanner.VerdictHigh:
+		return colorRed
+	case scanner.VerdictMedium:
+		return colorYellow
+	default:
+		return colorGreen
 	}
+}
 
+// outputReport prints the document risk report.
+func outputReport(report scanner.Report, entityCount int, useColor bool) int {
+	header := "─── REPORT " + strings.Repeat("─", 45)
+	if useColor {
+		fmt.Printf("%s%s%s\n", colorBold, header, colorReset)
+		fmt.Printf("Risk score:   %s%s%d/100 (%s)%s\n", colorBold, verdictColor(report.Verdict), report.Score, report.Verdict, colorReset)
+	} else {
+		fmt.Println(header)
+		fmt.Printf("Risk score:   %d/100 (%s)\n", report.Score, report.Verdict)
+	}
+	fmt.Printf("Entities:     %d (%.1f per 1,000 characters)\n", report.Entities, report.Density)
+	fmt.Printf("Highest tier: %s\n", report.HighestTier)
+	fmt.Printf("Individuals:  %d\n", report.Individuals)
+	secrets := "no"
+	if report.Secrets {
+		secrets = "yes"
+	}
+	fmt.Printf("Secrets:      %s\n", secrets)
+	special := "none"
+	if len(report.SpecialCategories) > 0 {
+		special = strings.Join(report.SpecialCategories, ", ")
+	}
+	fmt.Printf("Special data: %s\n", special)
+	fmt.Printf("Re-identification risk: %.0f%%\n\n", report.Reidentification*100)
+	for _, line := range report.Explanation {
+		fmt.Printf("  %s\n", line)
+	}
 	fmt.Println()
 
 	if entityCount > 0 {
@@ -220,10 +423,29 @@ func outputPretty(result redactor.RedactResult, useColor bool) int {
 	return 0
 }
 
+// linkedTypes lists the distinct non-PERSON types in a cluster, e.g. " (EMAIL, PHONE)".
+func linkedTypes(c scanner.Cluster) string {
+	seen := make(map[string]bool)
+	var types []string
+	for _, e := range c.Entities {
+		if e.Type != "PERSON" && !seen[e.Type] {
+			seen[e.Type] = true
+			types = append(types, e.Type)
+		}
+	}
+	if len(types) == 0 {
+		return ""
+	}
+	sort.Strings(types)
+	return " (" + strings.Join(types, ", ") + ")"
+}
+
 func highlightEntities(text string, entities []scanner.Entity) string {
 	if len(entities) == 0 {
 		return text
 	}
+	// Offsets refer to the normalized text.
+	text = scanner.Normalize(text)
 
 	// Sort by Start ascending.
 	sorted := make([]scanner.Entity, len(entities))
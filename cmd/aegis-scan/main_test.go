@@ -10,6 +10,7 @@ import (
 
 	"github.com/svenplb/aegis-core/internal/redactor"
 	"github.com/svenplb/aegis-core/internal/restorer"
+	"github.com/svenplb/aegis-core/internal/scanner"
 )
 
 var testBinary string
@@ -230,6 +231,75 @@ func TestTextFlag(t *testing.T) {
 	}
 }
 
+func TestTranscriptFlag(t *testing.T) {
+	out, code, err := runBinary("--text", "card four one one one one one one one one one one one one one one one", "--json", "--transcript")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if code != 1 {
+		t.Errorf("exit code = %d, want 1", code)
+	}
+
+	var result redactor.RedactResult
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if want := "card [CREDIT_CARD_1]"; result.SanitizedText != want {
+		t.Errorf("sanitized text = %q, want %q", result.SanitizedText, want)
+	}
+}
+
+func TestRolePolicyFlag(t *testing.T) {
+	text := "Patientin Anna Weber, behandelt von Oberarzt Thomas Schmidt."
+	out, _, err := runBinary("--text", text, "--json", "--role-policy", "patient=pseudonymize,clinician=keep")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var result redactor.RedactResult
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if want := "Patientin [PATIENT_1], behandelt von Oberarzt Thomas Schmidt."; result.SanitizedText != want {
+		t.Errorf("sanitized text = %q, want %q", result.SanitizedText, want)
+	}
+
+	_, code, err := runBinary("--text", text, "--role-policy", "doctor=keep")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if code != 2 {
+		t.Errorf("unknown role: exit code = %d, want 2", code)
+	}
+}
+
+func TestReportFlag(t *testing.T) {
+	text := "Patientin Anna Weber ist HIV-positiv. IBAN DE44 5001 0517 5407 3249 31"
+	out, code, err := runBinary("--text", text, "--report", "--json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if code != 1 {
+		t.Errorf("exit code = %d, want 1", code)
+	}
+
+	var report scanner.Report
+	if err := json.Unmarshal([]byte(out), &report); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if report.HighestTier != scanner.TierSpecial || report.Verdict == scanner.VerdictLow {
+		t.Errorf("report = %+v, want special tier and a raised verdict", report)
+	}
+
+	out, _, err = runBinary("--text", text, "--report")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out, "REPORT") || !strings.Contains(out, "Special data: health") {
+		t.Errorf("report output missing sections:\n%s", out)
+	}
+}
+
 func TestRoundTrip(t *testing.T) {
 	samples := []string{
 		"medical_de.txt",
@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"crypto/subtle"
 	"encoding/json"
 	"flag"
 	"fmt"
@@ -11,6 +12,7 @@ import (
 	"os/signal"
 	"regexp"
 	"strconv"
+	"strings"
 	"syscall"
 	"time"
 
@@ -18,6 +20,7 @@ import (
 	"github.com/svenplb/aegis-core/internal/redactor"
 	"github.com/svenplb/aegis-core/internal/restorer"
 	"github.com/svenplb/aegis-core/internal/scanner"
+	"github.com/svenplb/aegis-core/internal/vault"
 )
 
 const version = "0.1.0"
@@ -25,18 +28,47 @@ const version = "0.1.0"
 // maxRequestBody is the maximum allowed request body size (1 MB).
 const maxRequestBody int64 = 1 << 20
 
-// scanRequest is the JSON shape for /api/scan and /api/redact.
+// scanRequest is the JSON shape for /api/scan, /api/redact and /api/report.
 type scanRequest struct {
 	Text string `json:"text"`
+	// ClusterTokens renders entities linked to a person as [PERSON_1_EMAIL]
+	// (/api/redact only).
+	ClusterTokens bool `json:"cluster_tokens,omitempty"`
+	// Transcript treats the text as a speech-to-text transcript, detecting
+	// numbers and dates spoken as words.
+	Transcript bool `json:"transcript,omitempty"`
+	// RolePolicy maps person roles to redact, keep or pseudonymize and
+	// overrides the configured policy per role (/api/redact only).
+	RolePolicy map[string]string `json:"role_policy,omitempty"`
+	// Amounts redacts monetary amounts as "token", "range" or "magnitude"
+	// and overrides the configured mode (/api/redact only).
+	Amounts string `json:"amounts,omitempty"`
+	// Addresses redacts addresses as "token", "city" or "country" and
+	// overrides the configured mode (/api/redact only).
+	Addresses string `json:"addresses,omitempty"`
+	// URLs redacts URLs as "token" or "components" and overrides the
+	// configured mode (/api/redact only).
+	URLs string `json:"urls,omitempty"`
+	// RiskThreshold redacts quasi-identifiers when the re-identification
+	// risk reaches it and overrides the configured threshold (/api/redact
+	// only).
+	RiskThreshold float64 `json:"risk_threshold,omitempty"`
+	// Strategies maps entity types to token, mask, partial, remove, label
+	// or type and overrides the configured strategy per type (/api/redact
+	// only).
+	Strategies map[string]string `json:"strategies,omitempty"`
 }
 
 // scanResponse is the JSON shape returned by /api/scan.
 type scanResponse struct {
-	Entities       []scanner.Entity `json:"entities"`
-	ProcessingTime int64            `json:"processing_time_ms"`
+	Entities       []scanner.Entity  `json:"entities"`
+	Clusters       []scanner.Cluster `json:"clusters,omitempty"`
+	Risk           scanner.Risk      `json:"risk"`
+	ProcessingTime int64             `json:"processing_time_ms"`
 }
 
-// restoreRequest is the JSON shape for /api/restore.
+// restoreRequest is the JSON shape for /api/restore. Pseudonyms are
+// restored from the vault and need no mappings.
 type restoreRequest struct {
 	Text     string            `json:"text"`
 	Mappings []redactor.Mapping `json:"mappings"`
@@ -58,17 +90,23 @@ type errorResponse struct {
 	Error string `json:"error"`
 }
 
+// allowedOrigin returns the CORS origin from AEGIS_CORS_ORIGINS, "*" by
+// default.
+func allowedOrigin() string {
+	if origin := os.Getenv("AEGIS_CORS_ORIGINS"); origin != "" {
+		return origin
+	}
+	return "*"
+}
+
 // corsMiddleware wraps a handler to add CORS headers and handle OPTIONS preflight.
 func corsMiddleware(next http.Handler) http.Handler {
-	allowedOrigin := os.Getenv("AEGIS_CORS_ORIGINS")
-	if allowedOrigin == "" {
-		allowedOrigin = "*"
-	}
+	allowedOrigin := allowedOrigin()
 
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Access-Control-Allow-Origin", allowedOrigin)
 		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, OPTIONS")
-		w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
+		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
 
 		if r.Method == http.MethodOptions {
 			w.WriteHeader(http.StatusNoContent)
@@ -91,16 +129,21 @@ func writeError(w http.ResponseWriter, status int, msg string) {
 	writeJSON(w, status, errorResponse{Error: msg})
 }
 
-// newMux creates the HTTP mux with all routes registered.
+// newMux creates the HTTP mux with all routes registered. redaction holds
+// the configured defaults for /api/redact, report those for /api/report;
+// v keeps the originals of pseudonyms for /api/restore and is nil unless
+// restoring them is enabled.
 // Exported for use in tests.
-func newMux(sc *scanner.CompositeScanner) *http.ServeMux {
+func newMux(sc *scanner.CompositeScanner, redaction config.RedactionConfig, report config.ReportConfig, v *vault.Vault) *http.ServeMux {
 	mux := http.NewServeMux()
+	scs := newScanners(sc)
 
 	mux.HandleFunc("/", handleUI)
 	mux.HandleFunc("/health", handleHealth)
-	mux.HandleFunc("/api/scan", handleScan(sc))
-	mux.HandleFunc("/api/redact", handleRedact(sc))
-	mux.HandleFunc("/api/restore", handleRestore())
+	mux.HandleFunc("/api/scan", handleScan(scs))
+	mux.HandleFunc("/api/redact", handleRedact(scs, redaction, v))
+	mux.HandleFunc("/api/report", handleReport(scs, report))
+	mux.HandleFunc("/api/restore", handleRestore(redaction, v))
 
 	return mux
 }
@@ -118,7 +161,7 @@ func handleHealth(w http.ResponseWriter, r *http.Request) {
 }
 
 // handleScan returns a handler that scans text for PII entities.
-func handleScan(sc *scanner.CompositeScanner) http.HandlerFunc {
+func handleScan(scs scanners) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodPost {
 			writeError(w, http.StatusMethodNotAllowed, "method not allowed")
@@ -139,18 +182,40 @@ func handleScan(sc *scanner.CompositeScanner) http.HandlerFunc {
 		}
 
 		start := time.Now()
-		entities := sc.Scan(req.Text)
+		entities := scs.scannerFor(req).Scan(req.Text)
 		elapsed := time.Since(start).Milliseconds()
 
 		writeJSON(w, http.StatusOK, scanResponse{
 			Entities:       entities,
+			Clusters:       scanner.Clusters(entities),
+			Risk:           scanner.AssessRisk(req.Text, entities),
 			ProcessingTime: elapsed,
 		})
 	}
 }
 
-// handleRedact returns a handler that scans and redacts text.
-func handleRedact(sc *scanner.CompositeScanner) http.HandlerFunc {
+// scanners holds the scanners a request can ask for. The transcript
+// scanner is built once, as TranscriptMode compiles its own patterns.
+type scanners struct {
+	text       *scanner.CompositeScanner
+	transcript *scanner.CompositeScanner
+}
+
+func newScanners(sc *scanner.CompositeScanner) scanners {
+	return scanners{text: sc, transcript: sc.TranscriptMode()}
+}
+
+// scannerFor returns the scanner matching the request options.
+func (s scanners) scannerFor(req scanRequest) *scanner.CompositeScanner {
+	if req.Transcript {
+		return s.transcript
+	}
+	return s.text
+}
+
+// handleRedact returns a handler that scans and redacts text. The mappings
+// of pseudonyms are put in v, if any, instead of the response.
+func handleRedact(scs scanners, redaction config.RedactionConfig, v *vault.Vault) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodPost {
 			writeError(w, http.StatusMethodNotAllowed, "method not allowed")
@@ -170,15 +235,123 @@ func handleRedact(sc *scanner.CompositeScanner) http.HandlerFunc {
 			return
 		}
 
-		entities := sc.Scan(req.Text)
-		result := redactor.Redact(req.Text, entities)
+		merged := make(map[string]string, len(redaction.RolePolicy)+len(req.RolePolicy))
+		for _, m := range []map[string]string{redaction.RolePolicy, req.RolePolicy} {
+			for role, action := range m {
+				merged[role] = action
+			}
+		}
+		policy, err := redactor.ParseRolePolicy(merged)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "role_policy: "+err.Error())
+			return
+		}
+		strategies, err := redaction.ParseStrategies(req.Strategies)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "strategies: "+err.Error())
+			return
+		}
+		amounts := redaction.Amounts
+		if req.Amounts != "" {
+			amounts = req.Amounts
+		}
+		amountMode, err := redactor.ParseAmountMode(amounts)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "amounts: "+err.Error())
+			return
+		}
+		addresses := redaction.Addresses
+		if req.Addresses != "" {
+			addresses = req.Addresses
+		}
+		addressMode, err := redactor.ParseAddressMode(addresses)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "addresses: "+err.Error())
+			return
+		}
+		urls := redaction.URLs
+		if req.URLs != "" {
+			urls = req.URLs
+		}
+		urlMode, err := redactor.ParseURLMode(urls)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "urls: "+err.Error())
+			return
+		}
+		riskThreshold := redaction.RiskThreshold
+		if req.RiskThreshold != 0 {
+			riskThreshold = req.RiskThreshold
+		}
+		if err := redactor.CheckRiskThreshold(riskThreshold); err != nil {
+			writeError(w, http.StatusBadRequest, "risk_threshold: "+err.Error())
+			return
+		}
+
+		entities := scs.scannerFor(req).Scan(req.Text)
+		result := redactor.Redact(req.Text, entities,
+			redactor.WithClusterTokens(req.ClusterTokens),
+			redactor.WithRolePolicy(policy),
+			redactor.WithAmountGeneralization(amountMode),
+			redactor.WithAddressGeneralization(addressMode),
+			redactor.WithURLRedaction(urlMode),
+			redactor.WithRiskThreshold(riskThreshold),
+			redactor.WithStrategies(strategies),
+		)
+
+		mappings := make([]redactor.Mapping, 0, len(result.Mappings))
+		var pseudonyms []redactor.Mapping
+		for _, m := range result.Mappings {
+			if m.Strategy == "pseudonym" {
+				pseudonyms = append(pseudonyms, m)
+			} else {
+				mappings = append(mappings, m)
+			}
+		}
+		if v != nil {
+			if err := v.Put(pseudonyms); err != nil {
+				writeError(w, http.StatusInternalServerError, err.Error())
+				return
+			}
+		}
+		result.Mappings = mappings
 
 		writeJSON(w, http.StatusOK, result)
 	}
 }
 
-// handleRestore returns a handler that restores redacted tokens.
-func handleRestore() http.HandlerFunc {
+// handleReport returns a handler that scans text and returns its document
+// risk report.
+func handleReport(scs scanners, report config.ReportConfig) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+			return
+		}
+
+		r.Body = http.MaxBytesReader(w, r.Body, maxRequestBody)
+
+		var req scanRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeError(w, http.StatusBadRequest, "invalid request body")
+			return
+		}
+
+		if req.Text == "" {
+			writeError(w, http.StatusBadRequest, "text field is required")
+			return
+		}
+
+		entities := scs.scannerFor(req).Scan(req.Text)
+		writeJSON(w, http.StatusOK, scanner.NewReport(req.Text, entities, scanner.WithReportWeights(report.Weights)))
+	}
+}
+
+// handleRestore returns a handler that restores redacted tokens from the
+// request's mappings. Pseudonyms of the configured keys are restored from
+// v only for requests that carry the configured restore token.
+func handleRestore(redaction config.RedactionConfig, v *vault.Vault) http.HandlerFunc {
+	pseudonyms, _ := redaction.Pseudonyms.Strategy() // validated on load
+	restoreToken := redaction.Pseudonyms.Restore()
 	return func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodPost {
 			writeError(w, http.StatusMethodNotAllowed, "method not allowed")
@@ -198,7 +371,26 @@ func handleRestore() http.HandlerFunc {
 			return
 		}
 
+		fromVault := false
+		if auth := r.Header.Get("Authorization"); auth != "" {
+			token, ok := strings.CutPrefix(auth, "Bearer ")
+			if !ok || restoreToken == "" || v == nil || pseudonyms == nil ||
+				subtle.ConstantTimeCompare([]byte(token), []byte(restoreToken)) != 1 {
+				writeError(w, http.StatusUnauthorized, "invalid restore token")
+				return
+			}
+			fromVault = true
+		}
+
 		restored := restorer.Restore(req.Text, req.Mappings)
+		if fromVault {
+			restored = restorer.RestoreWith(restored, func(token string) (string, bool) {
+				if !pseudonyms.Known(token) {
+					return "", false
+				}
+				return v.Lookup(token)
+			})
+		}
 
 		writeJSON(w, http.StatusOK, restoreResponse{Text: restored})
 	}
@@ -245,8 +437,25 @@ func main() {
 	// Create scanner (with optional NLP support via build tags).
 	sc, cleanup := initScanner(cfg, allowlist)
 	defer cleanup()
+	if cfg.Scanner.TestData == "drop" {
+		sc = sc.DropTestData()
+	}
+
+	// Keep the originals of pseudonyms for /api/restore if enabled. Any
+	// website could restore them with a CORS origin of "*".
+	var v *vault.Vault
+	if cfg.Redaction.Pseudonyms.Restore() != "" {
+		if allowedOrigin() == "*" {
+			log.Fatalf("pseudonyms.restore_token needs AEGIS_CORS_ORIGINS set to the allowed origin, not \"*\"")
+		}
+		var err error
+		if v, err = vault.Open(cfg.Redaction.Pseudonyms.Vault); err != nil {
+			log.Fatalf("failed to open vault: %v", err)
+		}
+		defer v.Close()
+	}
 
-	mux := newMux(sc)
+	mux := newMux(sc, cfg.Redaction, cfg.Report, v)
 	handler := corsMiddleware(mux)
 
 	addr := fmt.Sprintf(":%d", port)
@@ -5,15 +5,18 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
+	"github.com/svenplb/aegis-core/internal/config"
 	"github.com/svenplb/aegis-core/internal/scanner"
+	"github.com/svenplb/aegis-core/internal/vault"
 )
 
 // newTestServer creates a test HTTP server with the full mux and CORS middleware.
 func newTestServer() *httptest.Server {
 	sc := scanner.DefaultScanner(nil)
-	mux := newMux(sc)
+	mux := newMux(sc, config.RedactionConfig{}, config.ReportConfig{}, nil)
 	handler := corsMiddleware(mux)
 	return httptest.NewServer(handler)
 }
@@ -82,6 +85,357 @@ func TestScanEndpoint(t *testing.T) {
 	}
 }
 
+func TestRedactEndpoint_ClusterTokens(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+
+	payload := `{"text": "Thomas Schmidt\nthomas.schmidt@example.com", "cluster_tokens": true}`
+	resp, err := http.Post(ts.URL+"/api/redact", "application/json", bytes.NewBufferString(payload))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer resp.Body.Close()
+
+	var body struct {
+		SanitizedText string            `json:"sanitized_text"`
+		Clusters      []scanner.Cluster `json:"clusters"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if want := "[PERSON_1]\n[PERSON_1_EMAIL]"; body.SanitizedText != want {
+		t.Errorf("sanitized_text = %q, want %q", body.SanitizedText, want)
+	}
+	if len(body.Clusters) != 1 || body.Clusters[0].Name != "Thomas Schmidt" {
+		t.Errorf("expected one cluster for Thomas Schmidt, got %+v", body.Clusters)
+	}
+}
+
+func TestRedactEndpoint_RolePolicy(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+
+	payload := `{"text": "Zeugin Anna Weber, Beklagter Thomas Schmidt.", "role_policy": {"legal_party": "pseudonymize"}}`
+	resp, err := http.Post(ts.URL+"/api/redact", "application/json", bytes.NewBufferString(payload))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer resp.Body.Close()
+
+	var body struct {
+		SanitizedText string `json:"sanitized_text"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if want := "Zeugin [LEGAL_PARTY_1], Beklagter [LEGAL_PARTY_2]."; body.SanitizedText != want {
+		t.Errorf("sanitized_text = %q, want %q", body.SanitizedText, want)
+	}
+
+	payload = `{"text": "Zeugin Anna Weber", "role_policy": {"witness": "keep"}}`
+	resp, err = http.Post(ts.URL+"/api/redact", "application/json", bytes.NewBufferString(payload))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("unknown role: expected status 400, got %d", resp.StatusCode)
+	}
+}
+
+func TestRedactEndpoint_Amounts(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+
+	payload := `{"text": "Gesamtbetrag: 3.200,00 €", "amounts": "range"}`
+	resp, err := http.Post(ts.URL+"/api/redact", "application/json", bytes.NewBufferString(payload))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer resp.Body.Close()
+
+	var body struct {
+		SanitizedText string `json:"sanitized_text"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if want := "Gesamtbetrag: [AMOUNT 1k–5k EUR]"; body.SanitizedText != want {
+		t.Errorf("sanitized_text = %q, want %q", body.SanitizedText, want)
+	}
+
+	payload = `{"text": "Gesamtbetrag: 3.200,00 €", "amounts": "bucket"}`
+	resp, err = http.Post(ts.URL+"/api/redact", "application/json", bytes.NewBufferString(payload))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("unknown mode: expected status 400, got %d", resp.StatusCode)
+	}
+}
+
+func TestRedactEndpoint_Addresses(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+
+	payload := `{"text": "Anschrift: Gartenstraße 27, 10115 Berlin", "addresses": "city"}`
+	resp, err := http.Post(ts.URL+"/api/redact", "application/json", bytes.NewBufferString(payload))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer resp.Body.Close()
+
+	var body struct {
+		SanitizedText string `json:"sanitized_text"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if want := "Anschrift: [ADDRESS Berlin, Germany]"; body.SanitizedText != want {
+		t.Errorf("sanitized_text = %q, want %q", body.SanitizedText, want)
+	}
+
+	payload = `{"text": "Anschrift: Gartenstraße 27, 10115 Berlin", "addresses": "street"}`
+	resp, err = http.Post(ts.URL+"/api/redact", "application/json", bytes.NewBufferString(payload))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("unknown mode: expected status 400, got %d", resp.StatusCode)
+	}
+}
+
+func TestRedactEndpoint_URLComponents(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+
+	payload := `{"text": "https://intranet.firma.de/docs?user=thomas.schmidt", "urls": "components"}`
+	resp, err := http.Post(ts.URL+"/api/redact", "application/json", bytes.NewBufferString(payload))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer resp.Body.Close()
+
+	var body struct {
+		SanitizedText string `json:"sanitized_text"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if want := "https://intranet.firma.de/docs?user=[PERSON_1]"; body.SanitizedText != want {
+		t.Errorf("sanitized_text = %q, want %q", body.SanitizedText, want)
+	}
+}
+
+func TestScanEndpoint_Risk(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+
+	payload := `{"text": "DOB: 1985-03-12, zip 90210, sex: F."}`
+	resp, err := http.Post(ts.URL+"/api/scan", "application/json", bytes.NewBufferString(payload))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer resp.Body.Close()
+
+	var body scanResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if body.Risk.Level != scanner.RiskHigh || len(body.Risk.Combinations) != 1 {
+		t.Errorf("risk = %+v, want one high-risk combination", body.Risk)
+	}
+}
+
+func TestRedactEndpoint_RiskThreshold(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+
+	payload := `{"text": "DOB: 1985-03-12, zip 90210, sex: F.", "risk_threshold": 0.5}`
+	resp, err := http.Post(ts.URL+"/api/redact", "application/json", bytes.NewBufferString(payload))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer resp.Body.Close()
+
+	var body struct {
+		SanitizedText string `json:"sanitized_text"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if want := "DOB: [DATE_1], zip [QUASI_IDENTIFIER_1], sex: [QUASI_IDENTIFIER_2]."; body.SanitizedText != want {
+		t.Errorf("sanitized_text = %q, want %q", body.SanitizedText, want)
+	}
+
+	payload = `{"text": "DOB: 1985-03-12", "risk_threshold": 2}`
+	resp, err = http.Post(ts.URL+"/api/redact", "application/json", bytes.NewBufferString(payload))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("threshold out of range: expected status 400, got %d", resp.StatusCode)
+	}
+}
+
+func TestRedactEndpoint_Strategies(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+
+	payload := `{"text": "Karte 4012 8888 8888 1881, Mail thomas@firma.de", "strategies": {"CREDIT_CARD": "partial", "EMAIL": "type"}}`
+	resp, err := http.Post(ts.URL+"/api/redact", "application/json", bytes.NewBufferString(payload))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer resp.Body.Close()
+
+	var body struct {
+		SanitizedText string `json:"sanitized_text"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if want := "Karte **** **** **** 1881, Mail [EMAIL]"; body.SanitizedText != want {
+		t.Errorf("sanitized_text = %q, want %q", body.SanitizedText, want)
+	}
+
+	payload = `{"text": "Mail thomas@firma.de", "strategies": {"EMAIL": "hash"}}`
+	resp, err = http.Post(ts.URL+"/api/redact", "application/json", bytes.NewBufferString(payload))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("unknown strategy: expected status 400, got %d", resp.StatusCode)
+	}
+}
+
+func TestRedactEndpoint_PseudonymVault(t *testing.T) {
+	redaction := config.RedactionConfig{Pseudonyms: config.PseudonymConfig{
+		Active: "k2",
+		Keys:         map[string]string{"k1": "old-secret-0123456789", "k2": "new-secret-0123456789"},
+		RestoreToken: "restore-token-0123456789",
+	}}
+	ts := httptest.NewServer(newMux(scanner.DefaultScanner(nil), redaction, config.ReportConfig{}, vault.New()))
+	defer ts.Close()
+
+	redact := func(text string) (string, []any) {
+		payload, _ := json.Marshal(map[string]any{"text": text, "strategies": map[string]string{"PERSON": "pseudonym"}})
+		resp, err := http.Post(ts.URL+"/api/redact", "application/json", bytes.NewReader(payload))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		defer resp.Body.Close()
+		var body struct {
+			SanitizedText string `json:"sanitized_text"`
+			Mappings      []any  `json:"mappings"`
+		}
+		if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		return body.SanitizedText, body.Mappings
+	}
+
+	first, mappings := redact("Termin mit Thomas Schmidt am Montag.")
+	second, _ := redact("Rückruf von Thomas  Schmidt erbeten.")
+	token := strings.TrimSuffix(strings.TrimPrefix(first, "Termin mit "), " am Montag.")
+	if !strings.HasPrefix(token, "[PERSON_k2_") || !strings.Contains(second, token) {
+		t.Fatalf("pseudonyms not stable: %q, %q", first, second)
+	}
+	if len(mappings) != 0 {
+		t.Errorf("mappings = %v, want pseudonyms kept in the vault", mappings)
+	}
+
+	restore := func(auth string) (int, string) {
+		payload, _ := json.Marshal(map[string]string{"text": "Bitte " + token + " anrufen."})
+		req, _ := http.NewRequest(http.MethodPost, ts.URL+"/api/restore", bytes.NewReader(payload))
+		req.Header.Set("Content-Type", "application/json")
+		if auth != "" {
+			req.Header.Set("Authorization", auth)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		defer resp.Body.Close()
+		var restored struct {
+			Text string `json:"text"`
+		}
+		_ = json.NewDecoder(resp.Body).Decode(&restored)
+		return resp.StatusCode, restored.Text
+	}
+
+	if status, text := restore("Bearer restore-token-0123456789"); status != http.StatusOK || text != "Bitte Thomas Schmidt anrufen." {
+		t.Errorf("with restore token: %d %q, want the name restored", status, text)
+	}
+	if status, text := restore(""); status != http.StatusOK || strings.Contains(text, "Schmidt") {
+		t.Errorf("without restore token: %d %q, want the pseudonym kept", status, text)
+	}
+	if status, _ := restore("Bearer wrong"); status != http.StatusUnauthorized {
+		t.Errorf("wrong restore token: expected status 401, got %d", status)
+	}
+}
+
+func TestReportEndpoint(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+
+	payload := `{"text": "Patientin Anna Weber ist HIV-positiv. IBAN DE44 5001 0517 5407 3249 31"}`
+	resp, err := http.Post(ts.URL+"/api/report", "application/json", bytes.NewBufferString(payload))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", resp.StatusCode)
+	}
+	var report scanner.Report
+	if err := json.NewDecoder(resp.Body).Decode(&report); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if report.HighestTier != scanner.TierSpecial || report.Individuals != 1 || report.Score < 60 {
+		t.Errorf("report = %+v, want special tier, one individual and a score of at least 60", report)
+	}
+
+	resp, err = http.Get(ts.URL + "/api/report")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusMethodNotAllowed {
+		t.Errorf("GET: expected status 405, got %d", resp.StatusCode)
+	}
+}
+
+func TestScanEndpoint_Transcript(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+
+	payload := `{"text": "my number is zero one seven zero, one two three four five six seven", "transcript": true}`
+	resp, err := http.Post(ts.URL+"/api/scan", "application/json", bytes.NewBufferString(payload))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer resp.Body.Close()
+
+	var body struct {
+		Entities []scanner.Entity `json:"entities"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(body.Entities) != 1 || body.Entities[0].Type != "PHONE" {
+		t.Fatalf("expected one PHONE entity, got %+v", body.Entities)
+	}
+	if want := "zero one seven zero, one two three four five six seven"; body.Entities[0].Text != want {
+		t.Errorf("entity text = %q, want %q", body.Entities[0].Text, want)
+	}
+}
+
 func TestRedactEndpoint(t *testing.T) {
 	ts := newTestServer()
 	defer ts.Close()
@@ -213,8 +567,8 @@ func TestCORSHeaders(t *testing.T) {
 	}
 
 	headers := resp.Header.Get("Access-Control-Allow-Headers")
-	if headers != "Content-Type" {
-		t.Errorf("expected Access-Control-Allow-Headers 'Content-Type', got %q", headers)
+	if headers != "Content-Type, Authorization" {
+		t.Errorf("expected Access-Control-Allow-Headers 'Content-Type, Authorization', got %q", headers)
 	}
 }
 
@@ -267,7 +621,7 @@ func TestOptionsPreflightRequest(t *testing.T) {
 	}
 
 	headers := resp.Header.Get("Access-Control-Allow-Headers")
-	if headers != "Content-Type" {
-		t.Errorf("expected Access-Control-Allow-Headers 'Content-Type', got %q", headers)
+	if headers != "Content-Type, Authorization" {
+		t.Errorf("expected Access-Control-Allow-Headers 'Content-Type, Authorization', got %q", headers)
 	}
 }
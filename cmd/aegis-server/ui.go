@@ -198,15 +198,19 @@ const uiHTML = `<!DOCTYPE html>
   .tag[data-t="DATE"]{color:var(--c-date);background:color-mix(in srgb,var(--c-date) 12%,transparent)}
   .tag[data-t="ORG"]{color:var(--c-org);background:color-mix(in srgb,var(--c-org) 12%,transparent)}
   .tag[data-t="IBAN"]{color:var(--c-iban);background:color-mix(in srgb,var(--c-iban) 12%,transparent)}
+  .tag[data-t="BANK_ACCOUNT"]{color:var(--c-iban);background:color-mix(in srgb,var(--c-iban) 12%,transparent)}
   .tag[data-t="CREDIT_CARD"]{color:var(--c-cc);background:color-mix(in srgb,var(--c-cc) 12%,transparent)}
   .tag[data-t="ID_NUMBER"]{color:var(--c-id);background:color-mix(in srgb,var(--c-id) 12%,transparent)}
+  .tag[data-t="LEGAL_REFERENCE"]{color:var(--c-id);background:color-mix(in srgb,var(--c-id) 12%,transparent)}
   .tag[data-t="SSN"]{color:var(--c-ssn);background:color-mix(in srgb,var(--c-ssn) 12%,transparent)}
   .tag[data-t="IP_ADDRESS"]{color:var(--c-ip);background:color-mix(in srgb,var(--c-ip) 12%,transparent)}
   .tag[data-t="URL"]{color:var(--c-url);background:color-mix(in srgb,var(--c-url) 12%,transparent)}
   .tag[data-t="FINANCIAL"]{color:var(--c-fin);background:color-mix(in srgb,var(--c-fin) 12%,transparent)}
   .tag[data-t="MEDICAL"]{color:var(--c-med);background:color-mix(in srgb,var(--c-med) 12%,transparent)}
+  .tag[data-t="SENSITIVE_CATEGORY"]{color:var(--c-med);background:color-mix(in srgb,var(--c-med) 12%,transparent)}
   .tag[data-t="ADDRESS"]{color:var(--c-addr);background:color-mix(in srgb,var(--c-addr) 12%,transparent)}
   .tag[data-t="LOCATION"]{color:var(--c-addr);background:color-mix(in srgb,var(--c-addr) 12%,transparent)}
+  .tag[data-t="QUASI_IDENTIFIER"]{color:var(--c-date);background:color-mix(in srgb,var(--c-date) 12%,transparent)}
   .tag[data-t="SECRET"]{color:var(--c-secret);background:color-mix(in srgb,var(--c-secret) 12%,transparent)}
 
   /* ═ Score ═ */
@@ -38,7 +38,7 @@ func entityColor(entityType string) lipgloss.Color {
 		return lipgloss.Color("6") // cyan
 	case "SECRET", "FINANCIAL", "CREDIT_CARD":
 		return lipgloss.Color("1") // red
-	case "ADDRESS", "IBAN":
+	case "ADDRESS", "IBAN", "BANK_ACCOUNT":
 		return lipgloss.Color("2") // green
 	default:
 		return lipgloss.Color("3") // yellow
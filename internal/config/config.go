@@ -5,6 +5,8 @@ import (
 	"os"
 	"regexp"
 
+	"github.com/svenplb/aegis-core/internal/redactor"
+	"github.com/svenplb/aegis-core/internal/scanner"
 	"gopkg.in/yaml.v3"
 )
 
@@ -20,6 +22,107 @@ type CustomPattern struct {
 type ScannerConfig struct {
 	CustomPatterns []CustomPattern `yaml:"custom_patterns"`
 	Allowlist      []string        `yaml:"allowlist"`
+	// TestData controls well-known test values and documentation ranges
+	// (4111 1111 1111 1111, example.com): "keep" flags them with a lowered
+	// score, "drop" removes them from the results.
+	TestData string `yaml:"test_data"`
+}
+
+// RedactionConfig holds redaction-related settings.
+type RedactionConfig struct {
+	// RolePolicy decides per person role (patient, clinician, legal_party,
+	// family, business, title) whether names are redacted, kept or
+	// pseudonymized with a role token such as [PATIENT_1].
+	RolePolicy map[string]string `yaml:"role_policy"`
+	// Amounts decides how monetary amounts are redacted: "token"
+	// ([FINANCIAL_1]), "range" ([AMOUNT 1k–5k EUR]) or "magnitude"
+	// ([AMOUNT ~1k EUR]).
+	Amounts string `yaml:"amounts"`
+	// Addresses decides how addresses are redacted: "token" ([ADDRESS_1]),
+	// "city" ([ADDRESS Berlin, Germany]) or "country" ([ADDRESS Germany]).
+	Addresses string `yaml:"addresses"`
+	// URLs decides how URLs are redacted: "token" ([URL_1]) or
+	// "components" (only credentials, personal path segments and query
+	// values are replaced).
+	URLs string `yaml:"urls"`
+	// RiskThreshold forces the redaction of quasi-identifiers (birth date,
+	// postcode, gender, occupation) whose combination re-identifies a
+	// person with at least this probability. 0 disables it.
+	RiskThreshold float64 `yaml:"risk_threshold"`
+	// Strategies chooses per entity type how it is replaced: "token"
+	// ([EMAIL_1], the default), "mask" (***), "partial" (last four digits,
+	// first letters of names, email domain), "remove", "label"
+	// ([REDACTED]) or "type" ([EMAIL]).
+	Strategies map[string]string `yaml:"strategies"`
+	// Pseudonyms holds the keys of the "pseudonym" strategy.
+	Pseudonyms PseudonymConfig `yaml:"pseudonyms"`
+}
+
+// ParseStrategies merges overrides, e.g. from a flag or request, over the
+// configured strategies per entity type and parses them. The "pseudonym"
+// strategy is available if pseudonym keys are configured.
+func (r RedactionConfig) ParseStrategies(overrides map[string]string) (map[string]redactor.Strategy, error) {
+	merged := make(map[string]string, len(r.Strategies)+len(overrides))
+	for _, m := range []map[string]string{r.Strategies, overrides} {
+		for typ, name := range m {
+			merged[typ] = name
+		}
+	}
+	var extra []redactor.Strategy
+	pseudonyms, err := r.Pseudonyms.Strategy()
+	if err != nil {
+		return nil, err
+	}
+	if pseudonyms != nil {
+		extra = append(extra, pseudonyms)
+	}
+	return redactor.ParseStrategies(merged, extra...)
+}
+
+// PseudonymConfig holds the keys of keyed pseudonyms ([PERSON_k1_7f3a9c0d5e21b84a]).
+type PseudonymConfig struct {
+	// Active is the ID of the key new pseudonyms are made with.
+	Active string `yaml:"active"`
+	// Keys maps key IDs to secrets of at least 16 bytes. Values are
+	// expanded from the environment, e.g. "${AEGIS_PSEUDONYM_KEY}".
+	// Pseudonyms of a key are restored until it is removed.
+	Keys map[string]string `yaml:"keys"`
+	// Length is the number of hex characters of a pseudonym, 6 to 64
+	// (default 16).
+	Length int `yaml:"length"`
+	// Vault is the file aegis-server keeps the originals of pseudonyms in,
+	// for /api/restore. Required with RestoreToken.
+	Vault string `yaml:"vault"`
+	// RestoreToken enables restoring pseudonyms from the vault on
+	// /api/restore for requests that send it as a bearer token. Empty
+	// (the default) disables it. Expanded from the environment like Keys.
+	RestoreToken string `yaml:"restore_token"`
+}
+
+// Restore returns the token that enables restoring pseudonyms from the
+// vault, or "" if it is disabled.
+func (p PseudonymConfig) Restore() string {
+	return os.ExpandEnv(p.RestoreToken)
+}
+
+// Strategy returns the pseudonym strategy of the configured keys, or nil
+// if no keys are configured.
+func (p PseudonymConfig) Strategy() (*redactor.PseudonymStrategy, error) {
+	if len(p.Keys) == 0 {
+		return nil, nil
+	}
+	keys := make(map[string][]byte, len(p.Keys))
+	for id, secret := range p.Keys {
+		keys[id] = []byte(os.ExpandEnv(secret))
+	}
+	return redactor.NewPseudonymStrategy(p.Active, keys, p.Length)
+}
+
+// ReportConfig holds settings of the document risk report.
+type ReportConfig struct {
+	// Weights overrides the weight of entity types in the report score,
+	// e.g. SECRET: 40. Unlisted types weigh 2 to 25 by sensitivity tier.
+	Weights map[string]float64 `yaml:"weights"`
 }
 
 // LoggingConfig holds logging-related settings.
@@ -29,8 +132,10 @@ type LoggingConfig struct {
 
 // Config is the top-level aegis-core configuration.
 type Config struct {
-	Scanner ScannerConfig `yaml:"scanner"`
-	Logging LoggingConfig `yaml:"logging"`
+	Scanner   ScannerConfig   `yaml:"scanner"`
+	Redaction RedactionConfig `yaml:"redaction"`
+	Report    ReportConfig    `yaml:"report"`
+	Logging   LoggingConfig   `yaml:"logging"`
 }
 
 // validLogLevels enumerates accepted log level strings.
@@ -41,6 +146,12 @@ var validLogLevels = map[string]bool{
 	"error": true,
 }
 
+// validTestDataModes enumerates accepted scanner.test_data values.
+var validTestDataModes = map[string]bool{
+	"keep": true,
+	"drop": true,
+}
+
 // Load reads a YAML configuration file from path and returns a Config.
 // Missing optional fields are filled from DefaultConfig.
 func Load(path string) (*Config, error) {
@@ -62,7 +173,7 @@ func Load(path string) (*Config, error) {
 }
 
 // Validate checks that every custom pattern regex compiles and that the
-// log level is recognised.
+// test data mode, role policy and log level are recognised.
 func (c *Config) Validate() error {
 	for i, cp := range c.Scanner.CustomPatterns {
 		if _, err := regexp.Compile(cp.Pattern); err != nil {
@@ -76,6 +187,51 @@ func (c *Config) Validate() error {
 		}
 	}
 
+	if !validTestDataModes[c.Scanner.TestData] {
+		return fmt.Errorf("config: unknown test_data mode %q (want keep|drop)", c.Scanner.TestData)
+	}
+
+	if _, err := redactor.ParseRolePolicy(c.Redaction.RolePolicy); err != nil {
+		return fmt.Errorf("config: role_policy: %w", err)
+	}
+
+	if _, err := redactor.ParseAmountMode(c.Redaction.Amounts); err != nil {
+		return fmt.Errorf("config: amounts: %w", err)
+	}
+
+	if _, err := redactor.ParseAddressMode(c.Redaction.Addresses); err != nil {
+		return fmt.Errorf("config: addresses: %w", err)
+	}
+
+	if _, err := redactor.ParseURLMode(c.Redaction.URLs); err != nil {
+		return fmt.Errorf("config: urls: %w", err)
+	}
+
+	if _, err := c.Redaction.Pseudonyms.Strategy(); err != nil {
+		return fmt.Errorf("config: pseudonyms: %w", err)
+	}
+
+	if token := c.Redaction.Pseudonyms.Restore(); token != "" {
+		if len(token) < 16 {
+			return fmt.Errorf("config: pseudonyms: restore_token is shorter than 16 bytes")
+		}
+		if c.Redaction.Pseudonyms.Vault == "" {
+			return fmt.Errorf("config: pseudonyms: restore_token needs a vault file")
+		}
+	}
+
+	if _, err := c.Redaction.ParseStrategies(nil); err != nil {
+		return fmt.Errorf("config: strategies: %w", err)
+	}
+
+	if err := redactor.CheckRiskThreshold(c.Redaction.RiskThreshold); err != nil {
+		return fmt.Errorf("config: risk_threshold: %w", err)
+	}
+
+	if err := scanner.CheckReportWeights(c.Report.Weights); err != nil {
+		return fmt.Errorf("config: report weights: %w", err)
+	}
+
 	if !validLogLevels[c.Logging.Level] {
 		return fmt.Errorf("config: unknown log level %q (want debug|info|warn|error)", c.Logging.Level)
 	}
@@ -37,6 +37,34 @@ func TestLoadValidConfig(t *testing.T) {
 	if got := len(cfg.Scanner.Allowlist); got != 2 {
 		t.Fatalf("len(Allowlist) = %d, want 2", got)
 	}
+
+	if got := cfg.Redaction.RolePolicy["clinician"]; got != "keep" {
+		t.Errorf("Redaction.RolePolicy[clinician] = %q, want %q", got, "keep")
+	}
+	if got := cfg.Redaction.Amounts; got != "range" {
+		t.Errorf("Redaction.Amounts = %q, want %q", got, "range")
+	}
+	if got := cfg.Redaction.Addresses; got != "city" {
+		t.Errorf("Redaction.Addresses = %q, want %q", got, "city")
+	}
+	if got := cfg.Redaction.URLs; got != "components" {
+		t.Errorf("Redaction.URLs = %q, want %q", got, "components")
+	}
+	if got := cfg.Redaction.RiskThreshold; got != 0.5 {
+		t.Errorf("Redaction.RiskThreshold = %g, want 0.5", got)
+	}
+	if got := cfg.Redaction.Strategies["CREDIT_CARD"]; got != "partial" {
+		t.Errorf("Redaction.Strategies[CREDIT_CARD] = %q, want %q", got, "partial")
+	}
+	if got := cfg.Redaction.Pseudonyms.Active; got != "k2" {
+		t.Errorf("Redaction.Pseudonyms.Active = %q, want %q", got, "k2")
+	}
+	if got := len(cfg.Redaction.Pseudonyms.Keys); got != 2 {
+		t.Errorf("len(Redaction.Pseudonyms.Keys) = %d, want 2", got)
+	}
+	if got := cfg.Report.Weights["SECRET"]; got != 40 {
+		t.Errorf("Report.Weights[SECRET] = %g, want 40", got)
+	}
 }
 
 func TestLoadMissingFile(t *testing.T) {
@@ -67,6 +95,94 @@ func TestLoadInvalidLogLevel(t *testing.T) {
 	}
 }
 
+func TestLoadInvalidTestDataMode(t *testing.T) {
+	_, err := Load(testdataPath("invalid_test_data.yaml"))
+	if err == nil {
+		t.Fatal("expected error for invalid test_data mode, got nil")
+	}
+}
+
+func TestLoadInvalidRolePolicy(t *testing.T) {
+	_, err := Load(testdataPath("invalid_role_policy.yaml"))
+	if err == nil {
+		t.Fatal("expected error for invalid role_policy, got nil")
+	}
+}
+
+func TestLoadInvalidAmounts(t *testing.T) {
+	_, err := Load(testdataPath("invalid_amounts.yaml"))
+	if err == nil {
+		t.Fatal("expected error for invalid amounts mode, got nil")
+	}
+}
+
+func TestLoadInvalidAddresses(t *testing.T) {
+	_, err := Load(testdataPath("invalid_addresses.yaml"))
+	if err == nil {
+		t.Fatal("expected error for invalid addresses mode, got nil")
+	}
+}
+
+func TestLoadInvalidURLs(t *testing.T) {
+	_, err := Load(testdataPath("invalid_urls.yaml"))
+	if err == nil {
+		t.Fatal("expected error for invalid urls mode, got nil")
+	}
+}
+
+func TestLoadInvalidStrategies(t *testing.T) {
+	_, err := Load(testdataPath("invalid_strategies.yaml"))
+	if err == nil {
+		t.Fatal("expected error for unknown strategy, got nil")
+	}
+}
+
+func TestLoadInvalidPseudonyms(t *testing.T) {
+	_, err := Load(testdataPath("invalid_pseudonyms.yaml"))
+	if err == nil {
+		t.Fatal("expected error for unknown active pseudonym key, got nil")
+	}
+}
+
+func TestLoadPseudonymsWithoutKeys(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Redaction.Strategies = map[string]string{"PERSON": "pseudonym"}
+	if err := cfg.Validate(); err == nil {
+		t.Fatal("expected error for pseudonym strategy without keys, got nil")
+	}
+}
+
+func TestLoadShortRestoreToken(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Redaction.Pseudonyms.RestoreToken = "secret"
+	cfg.Redaction.Pseudonyms.Vault = "vault.jsonl"
+	if err := cfg.Validate(); err == nil {
+		t.Fatal("expected error for short restore_token, got nil")
+	}
+}
+
+func TestLoadRestoreTokenWithoutVault(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Redaction.Pseudonyms.RestoreToken = "restore-token-0123456789"
+	if err := cfg.Validate(); err == nil {
+		t.Fatal("expected error for restore_token without vault, got nil")
+	}
+}
+
+func TestLoadInvalidReportWeights(t *testing.T) {
+	_, err := Load(testdataPath("invalid_report_weights.yaml"))
+	if err == nil {
+		t.Fatal("expected error for negative report weight, got nil")
+	}
+}
+
+func TestLoadInvalidRiskThreshold(t *testing.T) {
+	_, err := Load(testdataPath("invalid_risk_threshold.yaml"))
+	if err == nil {
+		t.Fatal("expected error for risk_threshold out of range, got nil")
+	}
+}
+
 func TestLoadEmptyConfigMergesDefaults(t *testing.T) {
 	cfg, err := Load(testdataPath("empty.yaml"))
 	if err != nil {
@@ -77,6 +193,9 @@ func TestLoadEmptyConfigMergesDefaults(t *testing.T) {
 	if cfg.Logging.Level != def.Logging.Level {
 		t.Errorf("empty config Logging.Level = %q, want default %q", cfg.Logging.Level, def.Logging.Level)
 	}
+	if cfg.Scanner.TestData != def.Scanner.TestData {
+		t.Errorf("empty config Scanner.TestData = %q, want default %q", cfg.Scanner.TestData, def.Scanner.TestData)
+	}
 }
 
 func TestDefaultConfigIsValid(t *testing.T) {
@@ -6,6 +6,7 @@ func DefaultConfig() *Config {
 		Scanner: ScannerConfig{
 			CustomPatterns: nil,
 			Allowlist:      nil,
+			TestData:       "keep",
 		},
 		Logging: LoggingConfig{
 			Level: "info",
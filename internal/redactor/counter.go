@@ -1,6 +1,9 @@
 package redactor
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+)
 
 // Counter assigns incrementing placeholder tokens per entity type.
 // If the same original text is seen again, the previously assigned token is reused.
@@ -28,3 +31,20 @@ func (c *Counter) Next(entityType, originalText string) string {
 	c.seen[originalText] = tok
 	return tok
 }
+
+// NextRelated returns a token derived from a person token for an entity
+// linked to that person, e.g. [PERSON_1_EMAIL]. Further distinct values of
+// the same type are numbered: [PERSON_1_EMAIL_2].
+func (c *Counter) NextRelated(personToken, entityType, originalText string) string {
+	if tok, ok := c.seen[originalText]; ok {
+		return tok
+	}
+	prefix := strings.TrimSuffix(personToken, "]") + "_" + entityType
+	c.counts[prefix]++
+	tok := prefix + "]"
+	if n := c.counts[prefix]; n > 1 {
+		tok = fmt.Sprintf("%s_%d]", prefix, n)
+	}
+	c.seen[originalText] = tok
+	return tok
+}
@@ -0,0 +1,157 @@
+package redactor
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/svenplb/aegis-core/internal/scanner"
+)
+
+// AmountMode decides how FINANCIAL entities with a parsed Amount are
+// redacted.
+type AmountMode string
+
+const (
+	// AmountToken replaces the amount with a [FINANCIAL_n] token (the default).
+	AmountToken AmountMode = "token"
+	// AmountRange replaces the amount with the 1-5-10 bucket it falls in:
+	// "3.200,00 €" becomes [AMOUNT 1k–5k EUR].
+	AmountRange AmountMode = "range"
+	// AmountMagnitude replaces the amount with its nearest order of
+	// magnitude: "2.500 €" becomes [AMOUNT ~1k EUR], "7.800 €" [AMOUNT ~10k EUR].
+	AmountMagnitude AmountMode = "magnitude"
+)
+
+// WithAmountGeneralization sets how amounts are redacted. Generalized
+// amounts keep their analytical value but cannot be restored, so no mapping
+// is recorded for them.
+func WithAmountGeneralization(mode AmountMode) Option {
+	return func(o *options) { o.amountMode = mode }
+}
+
+// ParseAmountMode converts a mode read from a config file, flag or request.
+// The empty string selects AmountToken.
+func ParseAmountMode(s string) (AmountMode, error) {
+	switch m := AmountMode(s); m {
+	case "":
+		return AmountToken, nil
+	case AmountToken, AmountRange, AmountMagnitude:
+		return m, nil
+	}
+	return "", fmt.Errorf("unknown amount mode %q (want token|range|magnitude)", s)
+}
+
+// generalizeAmount renders a for mode, or returns "" if a is not
+// generalized.
+func generalizeAmount(a *scanner.Amount, mode AmountMode) string {
+	if a == nil || (mode != AmountRange && mode != AmountMagnitude) {
+		return ""
+	}
+	v, err := strconv.ParseFloat(a.Value, 64)
+	if err != nil {
+		return ""
+	}
+	var label string
+	switch {
+	case v < 1:
+		label = "0–1"
+		if mode == AmountMagnitude {
+			label = "~0"
+		}
+	case mode == AmountRange:
+		low := powerOfTen(v)
+		high := 5 * low
+		if v >= high {
+			low, high = high, 10*low
+		}
+		label = shortAmount(low) + "–" + shortAmount(high)
+	default:
+		p := powerOfTen(v)
+		// Round on a logarithmic scale: √10 lies halfway between p and 10p.
+		if v >= p*math.Sqrt(10) {
+			p *= 10
+		}
+		label = "~" + shortAmount(p)
+	}
+	return "[" + strings.TrimSpace("AMOUNT "+label+" "+a.Currency) + "]"
+}
+
+// powerOfTen returns the largest power of ten not above v (v >= 1).
+func powerOfTen(v float64) float64 {
+	p := 1.0
+	for p*10 <= v {
+		p *= 10
+	}
+	return p
+}
+
+// shortAmount formats a round amount with a k, M or B suffix: 500, 5k, 10M.
+func shortAmount(v float64) string {
+	for _, unit := range []struct {
+		size   float64
+		suffix string
+	}{{1e9, "B"}, {1e6, "M"}, {1e3, "k"}} {
+		if v >= unit.size {
+			return strconv.FormatFloat(v/unit.size, 'f', -1, 64) + unit.suffix
+		}
+	}
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+// AddressMode decides how ADDRESS entities are redacted.
+type AddressMode string
+
+const (
+	// AddressToken replaces the address with an [ADDRESS_n] token (the default).
+	AddressToken AddressMode = "token"
+	// AddressCity keeps the city and country and drops the street, house
+	// number and postcode: "Gartenstraße 27, 10115 Berlin" becomes
+	// [ADDRESS Berlin, Germany].
+	AddressCity AddressMode = "city"
+	// AddressCountry keeps only the country: [ADDRESS Germany].
+	AddressCountry AddressMode = "country"
+)
+
+// WithAddressGeneralization sets how addresses are redacted. Like
+// generalized amounts, generalized addresses are not restored.
+func WithAddressGeneralization(mode AddressMode) Option {
+	return func(o *options) { o.addressMode = mode }
+}
+
+// ParseAddressMode converts a mode read from a config file, flag or request.
+// The empty string selects AddressToken.
+func ParseAddressMode(s string) (AddressMode, error) {
+	switch m := AddressMode(s); m {
+	case "":
+		return AddressToken, nil
+	case AddressToken, AddressCity, AddressCountry:
+		return m, nil
+	}
+	return "", fmt.Errorf("unknown address mode %q (want token|city|country)", s)
+}
+
+// generalizeAddress renders a for mode, or returns "" if a is not
+// generalized. An address without a city is generalized to its country,
+// one without either gets a token.
+func generalizeAddress(a *scanner.Address, mode AddressMode) string {
+	if a == nil || (mode != AddressCity && mode != AddressCountry) {
+		return ""
+	}
+	country := a.Country
+	if name, ok := scanner.GeneralizeLocation(country); ok {
+		country = name
+	}
+	var parts []string
+	if mode == AddressCity && a.City != "" {
+		parts = append(parts, a.City)
+	}
+	if country != "" {
+		parts = append(parts, country)
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return "[ADDRESS " + strings.Join(parts, ", ") + "]"
+}
@@ -0,0 +1,112 @@
+package redactor
+
+import (
+	"testing"
+
+	"github.com/svenplb/aegis-core/internal/scanner"
+)
+
+func TestGeneralizeAmount(t *testing.T) {
+	cases := []struct {
+		value, currency string
+		mode            AmountMode
+		want            string
+	}{
+		{"3200.00", "EUR", AmountRange, "[AMOUNT 1k–5k EUR]"},
+		{"5000", "EUR", AmountRange, "[AMOUNT 5k–10k EUR]"},
+		{"999.99", "USD", AmountRange, "[AMOUNT 500–1k USD]"},
+		{"1500000", "HUF", AmountRange, "[AMOUNT 1M–5M HUF]"},
+		{"42.50", "", AmountRange, "[AMOUNT 10–50]"},
+		{"0.50", "EUR", AmountRange, "[AMOUNT 0–1 EUR]"},
+		{"2500", "EUR", AmountMagnitude, "[AMOUNT ~1k EUR]"},
+		{"7800", "EUR", AmountMagnitude, "[AMOUNT ~10k EUR]"},
+		{"3200", "CHF", AmountMagnitude, "[AMOUNT ~10k CHF]"},
+		{"3200", "EUR", AmountToken, ""},
+	}
+	for _, tc := range cases {
+		got := generalizeAmount(&scanner.Amount{Value: tc.value, Currency: tc.currency}, tc.mode)
+		if got != tc.want {
+			t.Errorf("generalizeAmount(%s %s, %s) = %q, want %q", tc.value, tc.currency, tc.mode, got, tc.want)
+		}
+	}
+}
+
+func TestRedact_AmountGeneralization(t *testing.T) {
+	text := "Gehalt 3.200,00 € brutto, BIC GIBAATWWXXX"
+	entities := []scanner.Entity{
+		{Start: 7, End: 19, Type: "FINANCIAL", Text: "3.200,00 €", Score: 0.9, Detector: "regex", Amount: &scanner.Amount{Value: "3200.00", Currency: "EUR"}},
+		{Start: 32, End: 43, Type: "FINANCIAL", Text: "GIBAATWWXXX", Score: 0.85, Detector: "regex"},
+	}
+
+	result := Redact(text, entities, WithAmountGeneralization(AmountRange))
+
+	want := "Gehalt [AMOUNT 1k–5k EUR] brutto, BIC [FINANCIAL_1]"
+	if result.SanitizedText != want {
+		t.Errorf("SanitizedText = %q, want %q", result.SanitizedText, want)
+	}
+	if len(result.Mappings) != 1 || result.Mappings[0].Token != "[FINANCIAL_1]" {
+		t.Errorf("Mappings = %v, want only the BIC", result.Mappings)
+	}
+}
+
+func TestParseAmountMode(t *testing.T) {
+	if m, err := ParseAmountMode(""); err != nil || m != AmountToken {
+		t.Errorf(`ParseAmountMode("") = %q, %v`, m, err)
+	}
+	if m, err := ParseAmountMode("range"); err != nil || m != AmountRange {
+		t.Errorf(`ParseAmountMode("range") = %q, %v`, m, err)
+	}
+	if _, err := ParseAmountMode("bucket"); err == nil {
+		t.Error(`ParseAmountMode("bucket") succeeded`)
+	}
+}
+
+func TestGeneralizeAddress(t *testing.T) {
+	berlin := &scanner.Address{Street: "Gartenstraße", HouseNumber: "27", Postcode: "10115", City: "Berlin", Country: "Deutschland"}
+	cases := []struct {
+		addr *scanner.Address
+		mode AddressMode
+		want string
+	}{
+		{berlin, AddressCity, "[ADDRESS Berlin, Germany]"},
+		{berlin, AddressCountry, "[ADDRESS Germany]"},
+		{berlin, AddressToken, ""},
+		{&scanner.Address{Street: "Fenian St", City: "Covina"}, AddressCity, "[ADDRESS Covina]"},
+		{&scanner.Address{Street: "rue de la Loi", Country: "Belgique"}, AddressCity, "[ADDRESS Belgium]"},
+		{&scanner.Address{Street: "rue de la Loi", HouseNumber: "42"}, AddressCountry, ""},
+	}
+	for _, tc := range cases {
+		if got := generalizeAddress(tc.addr, tc.mode); got != tc.want {
+			t.Errorf("generalizeAddress(%+v, %s) = %q, want %q", *tc.addr, tc.mode, got, tc.want)
+		}
+	}
+}
+
+func TestRedact_AddressGeneralization(t *testing.T) {
+	text := "Anschrift: Musterstraße 5/2/3, 1100 Wien"
+	entities := []scanner.Entity{
+		{Start: 11, End: 41, Type: "ADDRESS", Text: "Musterstraße 5/2/3, 1100 Wien", Score: 0.85, Detector: "regex",
+			Address: &scanner.Address{Street: "Musterstraße", HouseNumber: "5", Unit: "2/3", Postcode: "1100", City: "Wien", Country: "Austria"}},
+	}
+
+	result := Redact(text, entities, WithAddressGeneralization(AddressCity))
+
+	if want := "Anschrift: [ADDRESS Wien, Austria]"; result.SanitizedText != want {
+		t.Errorf("SanitizedText = %q, want %q", result.SanitizedText, want)
+	}
+	if len(result.Mappings) != 0 {
+		t.Errorf("Mappings = %v, want none", result.Mappings)
+	}
+}
+
+func TestParseAddressMode(t *testing.T) {
+	if m, err := ParseAddressMode(""); err != nil || m != AddressToken {
+		t.Errorf(`ParseAddressMode("") = %q, %v`, m, err)
+	}
+	if m, err := ParseAddressMode("country"); err != nil || m != AddressCountry {
+		t.Errorf(`ParseAddressMode("country") = %q, %v`, m, err)
+	}
+	if _, err := ParseAddressMode("street"); err == nil {
+		t.Error(`ParseAddressMode("street") succeeded`)
+	}
+}
@@ -5,6 +5,21 @@ type Mapping struct {
 	Token    string `json:"token"`    // e.g. "[PERSON_1]"
 	Original string `json:"original"` // e.g. "Thomas Schmidt"
 	Type     string `json:"type"`     // e.g. "PERSON"
+	// Canonical is the full value a partial mention refers to, e.g.
+	// "Thomas Schmidt" for "Schmidt"; both share one token.
+	Canonical string `json:"canonical,omitempty"`
+	// Strategy is the name of the Strategy that produced Token, e.g.
+	// "token" or "partial".
+	Strategy string `json:"strategy,omitempty"`
+	// Reversible is set when Token stands for Original alone and can be
+	// restored.
+	Reversible bool `json:"reversible,omitempty"`
+}
+
+// Restorable reports whether Token can be replaced by Original. Mappings
+// without a Strategy predate strategies and always hold a placeholder token.
+func (m Mapping) Restorable() bool {
+	return m.Token != "" && (m.Reversible || m.Strategy == "")
 }
 
 // MappingTable holds all token↔original mappings for a redaction session.
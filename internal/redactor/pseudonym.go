@@ -0,0 +1,134 @@
+package redactor
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/svenplb/aegis-core/internal/scanner"
+	"golang.org/x/text/unicode/norm"
+)
+
+// Pseudonym lengths in hex characters.
+const (
+	DefaultPseudonymLength = 16
+	minPseudonymLength     = 6
+	maxPseudonymLength     = sha256.Size * 2
+)
+
+// minKeySize is the shortest secret accepted for a pseudonym key.
+const minKeySize = 16
+
+// keyIDRe restricts key IDs to what can be embedded in a token.
+var keyIDRe = regexp.MustCompile(`^[a-z0-9]{1,16}$`)
+
+// pseudonymRe matches a pseudonym token: [PERSON_k1_7f3a9c0d5e21b84a].
+var pseudonymRe = regexp.MustCompile(`^\[([A-Z][A-Z0-9_]*)_([a-z0-9]{1,16})_([0-9a-f]{6,64})\]$`)
+
+// PseudonymStrategy replaces an entity with a keyed pseudonym,
+// [PERSON_k1_7f3a9c0d5e21b84a]: the HMAC-SHA256 of its type and normalized value
+// under the active key, prefixed with the key's ID. The same value gets the
+// same pseudonym in every document and request as long as the key stays
+// active, so redacted data can still be joined and counted.
+//
+// Pseudonyms cannot be reversed from the key; they are restored from a
+// vault that stores the mappings on the server (see the vault package).
+// To rotate keys, add a new key and make it active: new documents get
+// pseudonyms under the new key ID while those of the old keys are still
+// restored until their key is removed.
+type PseudonymStrategy struct {
+	active string
+	keys   map[string][]byte
+	length int
+}
+
+// NewPseudonymStrategy returns a PseudonymStrategy that pseudonymizes with
+// the key active and restores the pseudonyms of all keys. Key IDs are 1–16
+// lower-case letters and digits, secrets at least 16 bytes. length is the
+// number of hex characters of the HMAC in the token, 6 to 64 (0 for 16);
+// with n distinct values of a type, two of them share a pseudonym with a
+// probability of about n²/2^(4·length+1).
+func NewPseudonymStrategy(active string, keys map[string][]byte, length int) (*PseudonymStrategy, error) {
+	if length == 0 {
+		length = DefaultPseudonymLength
+	}
+	if length < minPseudonymLength || length > maxPseudonymLength {
+		return nil, fmt.Errorf("pseudonym length %d out of range (want %d–%d)", length, minPseudonymLength, maxPseudonymLength)
+	}
+	for id, secret := range keys {
+		if !keyIDRe.MatchString(id) {
+			return nil, fmt.Errorf("key ID %q must be 1–16 lower-case letters and digits", id)
+		}
+		if len(secret) < minKeySize {
+			return nil, fmt.Errorf("key %s is shorter than %d bytes", id, minKeySize)
+		}
+	}
+	if _, ok := keys[active]; !ok {
+		return nil, fmt.Errorf("active key %q not among the keys", active)
+	}
+	return &PseudonymStrategy{active: active, keys: keys, length: length}, nil
+}
+
+func (*PseudonymStrategy) Name() string { return "pseudonym" }
+
+func (s *PseudonymStrategy) Replace(ent scanner.Entity, _ func() string) string {
+	return s.pseudonym(s.active, ent.Type, pseudonymValue(ent))
+}
+
+// Reversible reports true: a pseudonym stands for one value, and a vault
+// restores it.
+func (*PseudonymStrategy) Reversible() bool { return true }
+
+// Known reports whether token is a pseudonym under one of the strategy's
+// keys. Pseudonyms of removed keys are no longer restored.
+func (s *PseudonymStrategy) Known(token string) bool {
+	m := pseudonymRe.FindStringSubmatch(token)
+	if m == nil {
+		return false
+	}
+	_, ok := s.keys[m[2]]
+	return ok && len(m[3]) == s.length
+}
+
+// pseudonym computes the token of a normalized value of type typ under key
+// id.
+func (s *PseudonymStrategy) pseudonym(id, typ, value string) string {
+	mac := hmac.New(sha256.New, s.keys[id])
+	mac.Write([]byte(typ))
+	mac.Write([]byte{0})
+	mac.Write([]byte(value))
+	sum := hex.EncodeToString(mac.Sum(nil))
+	return "[" + typ + "_" + id + "_" + sum[:s.length] + "]"
+}
+
+// pseudonymValue normalizes an entity's value so that spellings of the same
+// value share a pseudonym: later mentions of a person count as the full
+// name, case and spacing are ignored, and numbers lose their separators
+// ("DE89 3704…" and "de8937 04…").
+func pseudonymValue(ent scanner.Entity) string {
+	value := ent.Text
+	if ent.Canonical != "" {
+		value = ent.Canonical
+	}
+	return PseudonymValue(ent.Type, value)
+}
+
+// PseudonymValue returns the normalized form of a value of type typ that
+// its pseudonym is computed from.
+func PseudonymValue(typ, value string) string {
+	value = strings.ToLower(norm.NFC.String(value))
+	switch typ {
+	case "CREDIT_CARD", "IBAN", "BANK_ACCOUNT", "PHONE", "SSN", "ID_NUMBER":
+		return strings.Map(func(r rune) rune {
+			if unicode.IsLetter(r) || unicode.IsDigit(r) {
+				return r
+			}
+			return -1
+		}, value)
+	}
+	return strings.Join(strings.Fields(value), " ")
+}
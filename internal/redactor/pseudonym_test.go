@@ -0,0 +1,97 @@
+package redactor
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/svenplb/aegis-core/internal/scanner"
+)
+
+var testKeys = map[string][]byte{
+	"k1": []byte("old-secret-0123456789"),
+	"k2": []byte("new-secret-0123456789"),
+}
+
+func TestPseudonymStrategy_Stable(t *testing.T) {
+	ps, err := NewPseudonymStrategy("k1", testKeys, 0)
+	if err != nil {
+		t.Fatalf("NewPseudonymStrategy: %v", err)
+	}
+	opt := WithStrategies(map[string]Strategy{"PERSON": ps, "IBAN": ps})
+	sc := scanner.DefaultScanner(nil)
+
+	first := "Thomas Schmidt, IBAN DE44 5001 0517 5407 3249 31"
+	second := "Überweisung an thomas  schmidt? Nein, an Anna Weber. IBAN DE44500105175407324931, Thomas Schmidt"
+	a := Redact(first, sc.Scan(first), opt)
+	b := Redact(second, sc.Scan(second), opt)
+
+	tokens := make(map[string]string)
+	for _, m := range a.Mappings {
+		if !strings.HasPrefix(m.Token, "["+m.Type+"_k1_") || len(m.Token) != len("["+m.Type+"_k1_]")+DefaultPseudonymLength {
+			t.Errorf("token %q has the wrong form", m.Token)
+		}
+		if m.Strategy != "pseudonym" || !m.Reversible {
+			t.Errorf("mapping %+v is not a reversible pseudonym", m)
+		}
+		tokens[m.Type] = m.Token
+	}
+	for typ, token := range tokens {
+		if !strings.Contains(b.SanitizedText, token) {
+			t.Errorf("%s pseudonym %s missing from second document: %q", typ, token, b.SanitizedText)
+		}
+	}
+	if c := Redact(first, sc.Scan(first), opt); c.SanitizedText != a.SanitizedText {
+		t.Errorf("pseudonyms differ between calls: %q, %q", a.SanitizedText, c.SanitizedText)
+	}
+}
+
+func TestPseudonymStrategy_Rotation(t *testing.T) {
+	old, _ := NewPseudonymStrategy("k1", testKeys, 0)
+	rotated, _ := NewPseudonymStrategy("k2", testKeys, 0)
+	retired, _ := NewPseudonymStrategy("k2", map[string][]byte{"k2": testKeys["k2"]}, 0)
+	ent := scanner.Entity{Type: "PERSON", Text: "Thomas Schmidt"}
+
+	before, after := old.Replace(ent, nil), rotated.Replace(ent, nil)
+	if !strings.HasPrefix(after, "[PERSON_k2_") || before == after {
+		t.Fatalf("rotation: %s → %s", before, after)
+	}
+	if !rotated.Known(before) || !rotated.Known(after) {
+		t.Error("pseudonyms of configured keys are not known")
+	}
+	if retired.Known(before) {
+		t.Error("pseudonym of a removed key is still known")
+	}
+	if rotated.Known("[PERSON_1]") {
+		t.Error("placeholder token taken for a pseudonym")
+	}
+}
+
+func TestNewPseudonymStrategy_Invalid(t *testing.T) {
+	cases := []struct {
+		active string
+		keys   map[string][]byte
+		length int
+	}{
+		{"k3", testKeys, 0},
+		{"K1", map[string][]byte{"K1": testKeys["k1"]}, 0},
+		{"k1", map[string][]byte{"k1": []byte("short")}, 0},
+		{"k1", testKeys, 4},
+		{"k1", testKeys, 65},
+	}
+	for _, tc := range cases {
+		if _, err := NewPseudonymStrategy(tc.active, tc.keys, tc.length); err == nil {
+			t.Errorf("NewPseudonymStrategy(%q, %d keys, %d) accepted", tc.active, len(tc.keys), tc.length)
+		}
+	}
+}
+
+func TestParseStrategies_Pseudonym(t *testing.T) {
+	if _, err := ParseStrategies(map[string]string{"PERSON": "pseudonym"}); err == nil {
+		t.Error("pseudonym accepted without keys")
+	}
+	ps, _ := NewPseudonymStrategy("k1", testKeys, 0)
+	got, err := ParseStrategies(map[string]string{"PERSON": "pseudonym"}, ps)
+	if err != nil || got["PERSON"] != Strategy(ps) {
+		t.Errorf("ParseStrategies = %v, %v", got, err)
+	}
+}
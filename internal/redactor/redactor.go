@@ -1,29 +1,110 @@
 package redactor
 
 import (
+	"fmt"
+	"slices"
 	"sort"
+	"strings"
 	"time"
 
 	"github.com/svenplb/aegis-core/internal/scanner"
-	"golang.org/x/text/unicode/norm"
 )
 
 // RedactResult holds the output of a Redact call.
 type RedactResult struct {
-	OriginalText   string           `json:"original_text"`
-	SanitizedText  string           `json:"sanitized_text"`
-	Entities       []scanner.Entity `json:"entities"`
-	Mappings       []Mapping        `json:"mappings"`
-	ProcessingTime int64            `json:"processing_time_ms"`
+	OriginalText   string            `json:"original_text"`
+	SanitizedText  string            `json:"sanitized_text"`
+	Entities       []scanner.Entity  `json:"entities"`
+	Mappings       []Mapping         `json:"mappings"`
+	Clusters       []scanner.Cluster `json:"clusters,omitempty"`
+	ProcessingTime int64             `json:"processing_time_ms"`
+	// Risk is the re-identification risk the policy of WithRiskThreshold
+	// was applied to; nil without the option.
+	Risk *scanner.Risk `json:"risk,omitempty"`
+}
+
+// Option configures Redact.
+type Option func(*options)
+
+type options struct {
+	clusterTokens bool
+	rolePolicy    map[string]Action
+	amountMode    AmountMode
+	addressMode   AddressMode
+	urlMode       URLMode
+	riskThreshold float64
+	strategies    map[string]Strategy
+}
+
+// WithClusterTokens renders entities linked to an identity cluster relative
+// to the person they belong to: [PERSON_1_EMAIL], [PERSON_1_PHONE].
+// Without it every entity gets its own [TYPE_n] token.
+func WithClusterTokens(enabled bool) Option {
+	return func(o *options) { o.clusterTokens = enabled }
+}
+
+// Action decides what happens to a PERSON entity with a given Role.
+type Action string
+
+const (
+	// ActionRedact replaces the name with a [PERSON_n] token (the default).
+	ActionRedact Action = "redact"
+	// ActionKeep leaves the name in the text; no mapping is recorded.
+	ActionKeep Action = "keep"
+	// ActionPseudonymize replaces the name with a token naming its role,
+	// e.g. [PATIENT_1] or [CLINICIAN_1], that is restored like any other.
+	ActionPseudonymize Action = "pseudonymize"
+)
+
+// WithRolePolicy sets the action per person role (scanner.RolePatient,
+// scanner.RoleClinician, ...). Persons without a role or whose role is not
+// in policy are redacted.
+func WithRolePolicy(policy map[string]Action) Option {
+	return func(o *options) { o.rolePolicy = policy }
+}
+
+// ParseRolePolicy converts a role → action map read from a config file or
+// request into a policy for WithRolePolicy, rejecting unknown roles and
+// actions.
+func ParseRolePolicy(m map[string]string) (map[string]Action, error) {
+	policy := make(map[string]Action, len(m))
+	for role, action := range m {
+		if !slices.Contains(scanner.Roles, role) {
+			return nil, fmt.Errorf("unknown role %q (want %s)", role, strings.Join(scanner.Roles, "|"))
+		}
+		switch a := Action(action); a {
+		case ActionRedact, ActionKeep, ActionPseudonymize:
+			policy[role] = a
+		default:
+			return nil, fmt.Errorf("unknown action %q for role %q (want redact|keep|pseudonymize)", action, role)
+		}
+	}
+	return policy, nil
 }
 
 // Redact replaces every entity span in text with a placeholder token and
 // returns the sanitised text together with the mapping table.
-func Redact(text string, entities []scanner.Entity) RedactResult {
+func Redact(text string, entities []scanner.Entity, opts ...Option) RedactResult {
 	start := time.Now()
 
-	// NFC-normalize so byte offsets from the scanner (which also NFC-normalizes) match.
-	text = norm.NFC.String(text)
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	// Normalize so byte offsets from the scanner (which also normalizes) match.
+	text = scanner.Normalize(text)
+
+	// Quasi-identifiers of a risky combination are redacted in full.
+	var risk *scanner.Risk
+	var forced map[span]bool
+	if o.riskThreshold > 0 {
+		var r scanner.Risk
+		var added []scanner.Entity
+		r, added, forced = forceQuasiIdentifiers(text, entities, o.riskThreshold)
+		risk = &r
+		entities = append(slices.Clip(entities), added...)
+	}
 
 	if len(entities) == 0 {
 		return RedactResult{
@@ -31,6 +112,7 @@ func Redact(text string, entities []scanner.Entity) RedactResult {
 			SanitizedText:  text,
 			Entities:       entities,
 			Mappings:       nil,
+			Risk:           risk,
 			ProcessingTime: time.Since(start).Milliseconds(),
 		}
 	}
@@ -41,16 +123,102 @@ func Redact(text string, entities []scanner.Entity) RedactResult {
 	sort.Slice(sorted, func(i, j int) bool {
 		return sorted[i].Start < sorted[j].Start
 	})
+	clusters := scanner.Clusters(sorted)
+
+	// Persons whose role the policy keeps stay in the text.
+	clusterRoles := make(map[int]string)
+	redacted := make([]scanner.Entity, 0, len(sorted))
+	for _, ent := range sorted {
+		if ent.Type == "PERSON" && ent.Role != "" {
+			clusterRoles[ent.Cluster] = ent.Role
+			if o.rolePolicy[ent.Role] == ActionKeep {
+				continue
+			}
+		}
+		redacted = append(redacted, ent)
+	}
 
 	// First pass: assign tokens in forward order so numbering matches reading order.
 	counter := NewCounter()
 	type tagged struct {
 		ent   scanner.Entity
 		token string
+		// generalized tokens stand for a range of values or a coarser place
+		// and are not restored.
+		generalized bool
+		strategy    Strategy
+	}
+	personToken := func(name, role string) string {
+		if o.rolePolicy[role] == ActionPseudonymize {
+			return counter.Next(strings.ToUpper(role), name)
+		}
+		return counter.Next("PERSON", name)
+	}
+	var clusterNames map[int]string
+	if o.clusterTokens {
+		clusterNames = make(map[int]string)
+		for _, c := range clusters {
+			// Details of a kept person get tokens of their own.
+			if o.rolePolicy[clusterRoles[c.ID]] != ActionKeep {
+				clusterNames[c.ID] = c.Name
+			}
+		}
+	}
+	entityToken := func(ent scanner.Entity) string {
+		// Partial mentions ("Schmidt" for "Thomas Schmidt") share the token
+		// of the entity they refer to.
+		original := ent.Text
+		if ent.Canonical != "" {
+			original = ent.Canonical
+		}
+		if name, ok := clusterNames[ent.Cluster]; ok && ent.Type != "PERSON" {
+			return counter.NextRelated(personToken(name, clusterRoles[ent.Cluster]), ent.Type, original)
+		}
+		if ent.Type == "PERSON" {
+			return personToken(original, ent.Role)
+		}
+		return counter.Next(ent.Type, original)
+	}
+	// The routing codes of a linked bank account are rendered relative to
+	// its account number: [BANK_ACCOUNT_1] and [BANK_ACCOUNT_1_SORT_CODE].
+	accounts := make(map[int]scanner.Entity)
+	for _, ent := range redacted {
+		if ent.Link != 0 && ent.Subtype == "account_number" {
+			accounts[ent.Link] = ent
+		}
+	}
+	// tag replaces ent as its type's strategy decides.
+	tag := func(ent scanner.Entity, token func() string) tagged {
+		s, ok := o.strategies[ent.Type]
+		if !ok {
+			s = TokenStrategy{}
+		}
+		return tagged{ent: ent, token: s.Replace(ent, token), strategy: s}
 	}
-	tags := make([]tagged, len(sorted))
-	for i, ent := range sorted {
-		tags[i] = tagged{ent: ent, token: counter.Next(ent.Type, ent.Text)}
+	tags := make([]tagged, 0, len(redacted))
+	for _, ent := range redacted {
+		if g := generalizeAmount(ent.Amount, o.amountMode); g != "" {
+			tags = append(tags, tagged{ent: ent, token: g, generalized: true})
+			continue
+		}
+		if g := generalizeAddress(ent.Address, o.addressMode); g != "" && !forced[span{ent.Start, ent.End}] {
+			tags = append(tags, tagged{ent: ent, token: g, generalized: true})
+			continue
+		}
+		// In component mode only the sensitive parts of a URL are replaced;
+		// a URL without any stays in the text.
+		if ent.Type == "URL" && o.urlMode == URLComponents {
+			for _, part := range ent.Parts {
+				tags = append(tags, tag(part, func() string { return entityToken(part) }))
+			}
+			continue
+		}
+		tags = append(tags, tag(ent, func() string {
+			if account, ok := accounts[ent.Link]; ok && ent.Subtype != "account_number" {
+				return counter.NextRelated(entityToken(account), strings.ToUpper(ent.Subtype), ent.Text)
+			}
+			return entityToken(ent)
+		}))
 	}
 
 	// Second pass: replace in reverse order to preserve byte offsets.
@@ -65,19 +233,39 @@ func Redact(text string, entities []scanner.Entity) RedactResult {
 		newBuf = append(newBuf, buf[t.ent.End:]...)
 		buf = newBuf
 
+		if t.generalized {
+			continue
+		}
 		mappings = append(mappings, Mapping{
-			Token:    t.token,
-			Original: t.ent.Text,
-			Type:     t.ent.Type,
+			Token:      t.token,
+			Original:   t.ent.Text,
+			Type:       t.ent.Type,
+			Canonical:  t.ent.Canonical,
+			Strategy:   t.strategy.Name(),
+			Reversible: t.strategy.Reversible(),
 		})
 	}
+	slices.Reverse(mappings)
 
-	// Deduplicate mappings (same token may appear multiple times).
-	seen := make(map[string]bool, len(mappings))
+	// Deduplicate mappings (same token may appear multiple times; masks
+	// and labels stand for several originals). A token that stands for
+	// several surface forms of one value ("Thomas Schmidt", "Schmidt")
+	// keeps a mapping per occurrence, in reading order, so that each is
+	// restored as written.
+	forms := make(map[string]map[string]bool)
+	for _, m := range mappings {
+		if m.Reversible {
+			if forms[m.Token] == nil {
+				forms[m.Token] = make(map[string]bool)
+			}
+			forms[m.Token][m.Original] = true
+		}
+	}
+	seen := make(map[Mapping]bool, len(mappings))
 	deduped := make([]Mapping, 0, len(mappings))
 	for _, m := range mappings {
-		if !seen[m.Token] {
-			seen[m.Token] = true
+		if !seen[m] || len(forms[m.Token]) > 1 {
+			seen[m] = true
 			deduped = append(deduped, m)
 		}
 	}
@@ -87,6 +275,8 @@ func Redact(text string, entities []scanner.Entity) RedactResult {
 		SanitizedText:  string(buf),
 		Entities:       entities,
 		Mappings:       deduped,
+		Clusters:       clusters,
+		Risk:           risk,
 		ProcessingTime: time.Since(start).Milliseconds(),
 	}
 }
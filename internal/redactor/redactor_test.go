@@ -1,6 +1,7 @@
 package redactor
 
 import (
+	"slices"
 	"testing"
 
 	"github.com/svenplb/aegis-core/internal/scanner"
@@ -82,6 +83,117 @@ func TestRedact_SameTextReusesToken(t *testing.T) {
 	}
 }
 
+func TestRedact_CanonicalSharesToken(t *testing.T) {
+	text := "Thomas Schmidt called. Later Schmidt's lawyer called."
+	entities := []scanner.Entity{
+		{Start: 0, End: 14, Type: "PERSON", Text: "Thomas Schmidt", Score: 0.85, Detector: "gazetteer"},
+		{Start: 29, End: 36, Type: "PERSON", Text: "Schmidt", Score: 0.77, Detector: "coreference", Canonical: "Thomas Schmidt"},
+	}
+
+	result := Redact(text, entities)
+
+	if want := "[PERSON_1] called. Later [PERSON_1]'s lawyer called."; result.SanitizedText != want {
+		t.Errorf("SanitizedText = %q, want %q", result.SanitizedText, want)
+	}
+	want := []Mapping{
+		{Token: "[PERSON_1]", Original: "Thomas Schmidt", Type: "PERSON", Strategy: "token", Reversible: true},
+		{Token: "[PERSON_1]", Original: "Schmidt", Type: "PERSON", Canonical: "Thomas Schmidt", Strategy: "token", Reversible: true},
+	}
+	if !slices.Equal(result.Mappings, want) {
+		t.Errorf("Mappings = %+v, want %+v", result.Mappings, want)
+	}
+}
+
+func TestRedact_ClusterTokens(t *testing.T) {
+	text := "Thomas Schmidt, thomas@example.com, +49 170 1234567"
+	entities := []scanner.Entity{
+		{Start: 0, End: 14, Type: "PERSON", Text: "Thomas Schmidt", Score: 0.85, Detector: "gazetteer", Cluster: 1},
+		{Start: 16, End: 34, Type: "EMAIL", Text: "thomas@example.com", Score: 0.99, Detector: "regex", Cluster: 1},
+		{Start: 36, End: 51, Type: "PHONE", Text: "+49 170 1234567", Score: 0.95, Detector: "regex", Cluster: 1},
+	}
+
+	plain := Redact(text, entities)
+	if want := "[PERSON_1], [EMAIL_1], [PHONE_1]"; plain.SanitizedText != want {
+		t.Errorf("without option: SanitizedText = %q, want %q", plain.SanitizedText, want)
+	}
+
+	result := Redact(text, entities, WithClusterTokens(true))
+	want := "[PERSON_1], [PERSON_1_EMAIL], [PERSON_1_PHONE]"
+	if result.SanitizedText != want {
+		t.Errorf("SanitizedText = %q, want %q", result.SanitizedText, want)
+	}
+	if len(result.Mappings) != 3 {
+		t.Fatalf("len(Mappings) = %d, want 3", len(result.Mappings))
+	}
+}
+
+func TestRedact_LinkedBankAccount(t *testing.T) {
+	text := "Routing 021000021, Account # 12345678. Refund to account # 12345678."
+	entities := []scanner.Entity{
+		{Start: 8, End: 17, Type: "BANK_ACCOUNT", Text: "021000021", Score: 0.95, Detector: "regex", Subtype: "aba_routing", Link: 1},
+		{Start: 29, End: 37, Type: "BANK_ACCOUNT", Text: "12345678", Score: 0.95, Detector: "regex", Subtype: "account_number", Link: 1},
+		{Start: 59, End: 67, Type: "BANK_ACCOUNT", Text: "12345678", Score: 0.95, Detector: "regex", Subtype: "account_number"},
+	}
+
+	result := Redact(text, entities)
+
+	want := "Routing [BANK_ACCOUNT_1_ABA_ROUTING], Account # [BANK_ACCOUNT_1]. Refund to account # [BANK_ACCOUNT_1]."
+	if result.SanitizedText != want {
+		t.Errorf("SanitizedText = %q, want %q", result.SanitizedText, want)
+	}
+	if len(result.Mappings) != 2 {
+		t.Fatalf("len(Mappings) = %d, want 2", len(result.Mappings))
+	}
+}
+
+func TestRedact_RolePolicy(t *testing.T) {
+	text := "Patientin Anna Weber, behandelt von Oberarzt Thomas Schmidt (t.schmidt@klinik.de)."
+	entities := []scanner.Entity{
+		{Start: 10, End: 20, Type: "PERSON", Text: "Anna Weber", Score: 0.95, Detector: "regex", Cluster: 1, Role: scanner.RolePatient},
+		{Start: 45, End: 59, Type: "PERSON", Text: "Thomas Schmidt", Score: 0.95, Detector: "regex", Cluster: 2, Role: scanner.RoleClinician},
+		{Start: 61, End: 80, Type: "EMAIL", Text: "t.schmidt@klinik.de", Score: 0.99, Detector: "regex", Cluster: 2},
+	}
+
+	plain := Redact(text, entities)
+	if want := "Patientin [PERSON_1], behandelt von Oberarzt [PERSON_2] ([EMAIL_1])."; plain.SanitizedText != want {
+		t.Errorf("without policy: SanitizedText = %q, want %q", plain.SanitizedText, want)
+	}
+
+	policy := map[string]Action{
+		scanner.RolePatient:   ActionPseudonymize,
+		scanner.RoleClinician: ActionKeep,
+	}
+	result := Redact(text, entities, WithRolePolicy(policy), WithClusterTokens(true))
+	want := "Patientin [PATIENT_1], behandelt von Oberarzt Thomas Schmidt ([EMAIL_1])."
+	if result.SanitizedText != want {
+		t.Errorf("SanitizedText = %q, want %q", result.SanitizedText, want)
+	}
+	for _, m := range result.Mappings {
+		if m.Original == "Thomas Schmidt" {
+			t.Errorf("kept person has a mapping: %v", m)
+		}
+		if m.Token == "[PATIENT_1]" && (m.Original != "Anna Weber" || m.Type != "PERSON") {
+			t.Errorf("pseudonym mapping = %v", m)
+		}
+	}
+}
+
+func TestParseRolePolicy(t *testing.T) {
+	policy, err := ParseRolePolicy(map[string]string{"patient": "redact", "clinician": "keep", "legal_party": "pseudonymize"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if policy["clinician"] != ActionKeep || policy["legal_party"] != ActionPseudonymize {
+		t.Errorf("policy = %v", policy)
+	}
+	if _, err := ParseRolePolicy(map[string]string{"doctor": "keep"}); err == nil {
+		t.Error("expected error for unknown role")
+	}
+	if _, err := ParseRolePolicy(map[string]string{"patient": "hide"}); err == nil {
+		t.Error("expected error for unknown action")
+	}
+}
+
 func TestRedact_UTF8Multibyte(t *testing.T) {
 	// German umlauts are multi-byte in UTF-8: Ä=2 bytes, ö=2, ü=2, ß=2.
 	text := "Herr Müller wohnt in Österreich."
@@ -162,3 +274,18 @@ func TestCounter_Next(t *testing.T) {
 		t.Errorf("tok4 = %q, want [EMAIL_1]", tok4)
 	}
 }
+
+func TestCounter_NextRelated(t *testing.T) {
+	c := NewCounter()
+	person := c.Next("PERSON", "Alice")
+
+	if tok := c.NextRelated(person, "EMAIL", "alice@example.com"); tok != "[PERSON_1_EMAIL]" {
+		t.Errorf("first email = %q, want [PERSON_1_EMAIL]", tok)
+	}
+	if tok := c.NextRelated(person, "EMAIL", "alice@work.example"); tok != "[PERSON_1_EMAIL_2]" {
+		t.Errorf("second email = %q, want [PERSON_1_EMAIL_2]", tok)
+	}
+	if tok := c.NextRelated(person, "EMAIL", "alice@example.com"); tok != "[PERSON_1_EMAIL]" {
+		t.Errorf("repeated email = %q, want [PERSON_1_EMAIL]", tok)
+	}
+}
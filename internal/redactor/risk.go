@@ -0,0 +1,62 @@
+package redactor
+
+import (
+	"fmt"
+
+	"github.com/svenplb/aegis-core/internal/scanner"
+)
+
+// WithRiskThreshold forces the redaction of quasi-identifiers when the
+// re-identification risk of the text (scanner.AssessRisk) reaches
+// threshold, a probability between 0 and 1. The values of every
+// combination scoring at least threshold are replaced: gender and
+// occupation words and bare postcodes, which are not entities otherwise,
+// get a [QUASI_IDENTIFIER_n] token, and addresses are not generalized.
+// 0 disables the policy.
+func WithRiskThreshold(threshold float64) Option {
+	return func(o *options) { o.riskThreshold = threshold }
+}
+
+// CheckRiskThreshold validates a threshold read from a config file, flag
+// or request.
+func CheckRiskThreshold(threshold float64) error {
+	if threshold < 0 || threshold > 1 {
+		return fmt.Errorf("risk threshold %g out of range (want 0–1, 0 to disable)", threshold)
+	}
+	return nil
+}
+
+// span is the byte range of an entity or quasi-identifier.
+type span struct{ start, end int }
+
+// forceQuasiIdentifiers assesses the risk of text and returns it together
+// with the entities to add for quasi-identifiers that are not entities yet
+// and the spans of entities that must not be generalized.
+func forceQuasiIdentifiers(text string, entities []scanner.Entity, threshold float64) (scanner.Risk, []scanner.Entity, map[span]bool) {
+	risk := scanner.AssessRisk(text, entities)
+	var added []scanner.Entity
+	forced := make(map[span]bool)
+	for _, c := range risk.Combinations {
+		if c.Score < threshold {
+			break // ordered by score
+		}
+		for _, q := range c.QuasiIdentifiers {
+			if forced[span{q.Start, q.End}] {
+				continue
+			}
+			forced[span{q.Start, q.End}] = true
+			if q.Type == scanner.QuasiIdentifierType {
+				added = append(added, scanner.Entity{
+					Start:    q.Start,
+					End:      q.End,
+					Type:     scanner.QuasiIdentifierType,
+					Text:     q.Text,
+					Score:    c.Score,
+					Detector: "risk",
+					Subtype:  q.Kind,
+				})
+			}
+		}
+	}
+	return risk, added, forced
+}
@@ -0,0 +1,91 @@
+package redactor
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/svenplb/aegis-core/internal/scanner"
+)
+
+func TestRedact_RiskThreshold(t *testing.T) {
+	text := "Patient, männlich, geboren am 12.03.1985, PLZ 10115, von Beruf Lehrer."
+	entities := scanner.DefaultScanner(nil).Scan(text)
+
+	result := Redact(text, entities)
+	if result.Risk != nil {
+		t.Errorf("Risk = %+v without the option, want nil", result.Risk)
+	}
+
+	result = Redact(text, entities, WithRiskThreshold(0.5))
+	want := "Patient, [QUASI_IDENTIFIER_1], geboren am [DATE_1], PLZ [QUASI_IDENTIFIER_2], von Beruf [QUASI_IDENTIFIER_3]."
+	if result.SanitizedText != want {
+		t.Errorf("SanitizedText = %q, want %q", result.SanitizedText, want)
+	}
+	if result.Risk == nil || result.Risk.Level != scanner.RiskHigh {
+		t.Errorf("Risk = %+v, want high", result.Risk)
+	}
+	if len(result.Mappings) != 4 {
+		t.Errorf("Mappings = %v, want 4", result.Mappings)
+	}
+}
+
+func TestRedact_RiskThresholdNotReached(t *testing.T) {
+	text := "Frau Weber ist Lehrerin."
+	entities := scanner.DefaultScanner(nil).Scan(text)
+
+	result := Redact(text, entities, WithRiskThreshold(0.5))
+	if result.SanitizedText != Redact(text, entities).SanitizedText {
+		t.Errorf("SanitizedText = %q, want it unchanged by the policy", result.SanitizedText)
+	}
+}
+
+func TestRedact_RiskThresholdOverridesGeneralization(t *testing.T) {
+	text := "Geboren am 12.03.1985, männlich. Anschrift: Gartenstraße 27, 10115 Berlin"
+	entities := scanner.DefaultScanner(nil).Scan(text)
+
+	result := Redact(text, entities, WithAddressGeneralization(AddressCity), WithRiskThreshold(0.5))
+	want := "Geboren am [DATE_1], [QUASI_IDENTIFIER_1]. Anschrift: [ADDRESS_1]"
+	if result.SanitizedText != want {
+		t.Errorf("SanitizedText = %q, want %q", result.SanitizedText, want)
+	}
+}
+
+func TestCheckRiskThreshold(t *testing.T) {
+	for _, v := range []float64{0, 0.5, 1} {
+		if err := CheckRiskThreshold(v); err != nil {
+			t.Errorf("CheckRiskThreshold(%g) = %v", v, err)
+		}
+	}
+	for _, v := range []float64{-0.1, 1.5} {
+		if err := CheckRiskThreshold(v); err == nil {
+			t.Errorf("CheckRiskThreshold(%g) succeeded", v)
+		}
+	}
+}
+
+func TestForceQuasiIdentifiers_SameStart(t *testing.T) {
+	// The bare postcode before the city starts where the address does; only
+	// the postcode belongs to the risky combination.
+	text := "Geboren am 12.03.1985, männlich, 10115 Berlin"
+	at := strings.Index(text, "10115")
+	entities := []scanner.Entity{
+		{Start: 11, End: 21, Type: "DATE", Text: "12.03.1985"},
+		{Start: at, End: at + 12, Type: "ADDRESS", Text: "10115 Berlin", Address: &scanner.Address{City: "Berlin"}},
+		{Start: at + 6, End: at + 12, Type: "LOCATION", Subtype: "city", Text: "Berlin"},
+	}
+
+	_, added, forced := forceQuasiIdentifiers(text, entities, 0.5)
+	if !forced[span{at, at + 5}] {
+		t.Errorf("forced = %v, want the postcode", forced)
+	}
+	if forced[span{at, at + 12}] {
+		t.Errorf("forced = %v, want the address left to generalization", forced)
+	}
+	var postcode bool
+	for _, e := range added {
+		postcode = postcode || e.Text == "10115"
+	}
+	if !postcode {
+		t.Errorf("added = %v, want the postcode 10115", added)
+	}
+}
@@ -0,0 +1,187 @@
+package redactor
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/svenplb/aegis-core/internal/scanner"
+)
+
+// Strategy decides what replaces an entity in the sanitized text.
+type Strategy interface {
+	// Name identifies the strategy in mappings, config files and requests.
+	Name() string
+	// Replace returns the text that replaces ent. token returns the
+	// entity's placeholder ([PERSON_1], [PERSON_1_EMAIL]); strategies call
+	// it only if they use it, so that placeholders are numbered without
+	// gaps.
+	Replace(ent scanner.Entity, token func() string) string
+	// Reversible reports whether the replacement stands for exactly one
+	// original, so that restoring it from its mapping is safe.
+	Reversible() bool
+}
+
+// TokenStrategy replaces an entity with its placeholder token, [EMAIL_1]
+// (the default). It is reversible.
+type TokenStrategy struct{}
+
+func (TokenStrategy) Name() string { return "token" }
+
+func (TokenStrategy) Replace(_ scanner.Entity, token func() string) string { return token() }
+
+func (TokenStrategy) Reversible() bool { return true }
+
+// MaskStrategy replaces an entity with "***".
+type MaskStrategy struct{}
+
+func (MaskStrategy) Name() string { return "mask" }
+
+func (MaskStrategy) Replace(scanner.Entity, func() string) string { return "***" }
+
+func (MaskStrategy) Reversible() bool { return false }
+
+// PartialMaskStrategy masks an entity but keeps the part that lets a reader
+// tell values apart: the last four characters of card, account, phone and
+// identity numbers ("**** **** **** 1111"), the first letter of every part
+// of a name ("T*** S***") and the domain of an email address
+// ("***@example.com"). Other entities are masked in full.
+type PartialMaskStrategy struct{}
+
+func (PartialMaskStrategy) Name() string { return "partial" }
+
+func (PartialMaskStrategy) Replace(ent scanner.Entity, _ func() string) string {
+	switch ent.Type {
+	case "CREDIT_CARD", "IBAN", "BANK_ACCOUNT", "PHONE", "SSN", "ID_NUMBER":
+		return maskKeepLast(ent.Text, 4)
+	case "PERSON":
+		words := strings.Fields(ent.Text)
+		for i, w := range words {
+			r := []rune(w)
+			words[i] = string(r[0]) + "***"
+		}
+		return strings.Join(words, " ")
+	case "EMAIL":
+		if at := strings.LastIndexByte(ent.Text, '@'); at >= 0 {
+			return "***" + ent.Text[at:]
+		}
+	}
+	return "***"
+}
+
+func (PartialMaskStrategy) Reversible() bool { return false }
+
+// maskKeepLast replaces every letter and digit of s with "*" except the
+// last n, keeping separators. Values with fewer than 2n letters and digits
+// are masked in full.
+func maskKeepLast(s string, n int) string {
+	total := 0
+	for _, r := range s {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			total++
+		}
+	}
+	if total < 2*n {
+		return "***"
+	}
+	var b strings.Builder
+	seen := 0
+	for _, r := range s {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			seen++
+			if seen <= total-n {
+				r = '*'
+			}
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// RemoveStrategy deletes an entity from the text.
+type RemoveStrategy struct{}
+
+func (RemoveStrategy) Name() string { return "remove" }
+
+func (RemoveStrategy) Replace(scanner.Entity, func() string) string { return "" }
+
+func (RemoveStrategy) Reversible() bool { return false }
+
+// LabelStrategy replaces every entity with the same fixed label.
+type LabelStrategy struct {
+	Label string // e.g. "[REDACTED]"
+}
+
+func (LabelStrategy) Name() string { return "label" }
+
+func (s LabelStrategy) Replace(scanner.Entity, func() string) string { return s.Label }
+
+func (LabelStrategy) Reversible() bool { return false }
+
+// TypeStrategy replaces an entity with its type alone: [EMAIL].
+type TypeStrategy struct{}
+
+func (TypeStrategy) Name() string { return "type" }
+
+func (TypeStrategy) Replace(ent scanner.Entity, _ func() string) string {
+	return "[" + ent.Type + "]"
+}
+
+func (TypeStrategy) Reversible() bool { return false }
+
+// strategies are the built-in strategies by name.
+var strategies = map[string]Strategy{
+	"token":   TokenStrategy{},
+	"mask":    MaskStrategy{},
+	"partial": PartialMaskStrategy{},
+	"remove":  RemoveStrategy{},
+	"label":   LabelStrategy{Label: "[REDACTED]"},
+	"type":    TypeStrategy{},
+}
+
+// strategyNames lists the built-in strategies for error messages.
+func strategyNames() string {
+	names := make([]string, 0, len(strategies))
+	for name := range strategies {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return strings.Join(names, "|")
+}
+
+// WithStrategies sets the strategy per entity type ("EMAIL", "CREDIT_CARD",
+// ...). Types without one get a placeholder token. Generalized amounts and
+// addresses keep their generalization; in URL component mode each part of
+// a URL gets the strategy of its own type.
+func WithStrategies(byType map[string]Strategy) Option {
+	return func(o *options) { o.strategies = byType }
+}
+
+// ParseStrategies converts an entity type → strategy name map read from a
+// config file, flag or request into strategies for WithStrategies. The
+// names are token, mask, partial, remove, label ([REDACTED]) and type, and
+// those of the configured strategies passed as extra, such as a
+// PseudonymStrategy.
+func ParseStrategies(m map[string]string, extra ...Strategy) (map[string]Strategy, error) {
+	byType := make(map[string]Strategy, len(m))
+	for typ, name := range m {
+		if typ == "" || typ != strings.ToUpper(typ) {
+			return nil, fmt.Errorf("entity type %q must be upper case, e.g. EMAIL", typ)
+		}
+		s, ok := strategies[name]
+		for _, e := range extra {
+			if e.Name() == name {
+				s, ok = e, true
+			}
+		}
+		if !ok {
+			if name == "pseudonym" {
+				return nil, fmt.Errorf("strategy pseudonym for %s needs pseudonym keys", typ)
+			}
+			return nil, fmt.Errorf("unknown strategy %q for %s (want %s)", name, typ, strategyNames())
+		}
+		byType[typ] = s
+	}
+	return byType, nil
+}
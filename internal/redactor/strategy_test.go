@@ -0,0 +1,81 @@
+package redactor
+
+import (
+	"testing"
+
+	"github.com/svenplb/aegis-core/internal/scanner"
+)
+
+func TestRedact_Strategies(t *testing.T) {
+	text := "Karte 4012 8888 8888 1881 von Thomas Schmidt, Tel. 030 1234567, Mail thomas@firma.de"
+	entities := scanner.DefaultScanner(nil).Scan(text)
+
+	cases := []struct {
+		strategies map[string]Strategy
+		want       string
+	}{
+		{nil, "Karte [CREDIT_CARD_1] von [PERSON_1], Tel. [PHONE_1], Mail [EMAIL_1]"},
+		{map[string]Strategy{"CREDIT_CARD": MaskStrategy{}, "EMAIL": MaskStrategy{}},
+			"Karte *** von [PERSON_1], Tel. [PHONE_1], Mail ***"},
+		{map[string]Strategy{"CREDIT_CARD": PartialMaskStrategy{}, "PERSON": PartialMaskStrategy{}, "EMAIL": PartialMaskStrategy{}},
+			"Karte **** **** **** 1881 von T*** S***, Tel. [PHONE_1], Mail ***@firma.de"},
+		{map[string]Strategy{"PHONE": RemoveStrategy{}},
+			"Karte [CREDIT_CARD_1] von [PERSON_1], Tel. , Mail [EMAIL_1]"},
+		{map[string]Strategy{"PERSON": LabelStrategy{Label: "[REDACTED]"}},
+			"Karte [CREDIT_CARD_1] von [REDACTED], Tel. [PHONE_1], Mail [EMAIL_1]"},
+		{map[string]Strategy{"CREDIT_CARD": TypeStrategy{}, "PHONE": TypeStrategy{}},
+			"Karte [CREDIT_CARD] von [PERSON_1], Tel. [PHONE], Mail [EMAIL_1]"},
+	}
+	for _, tc := range cases {
+		result := Redact(text, entities, WithStrategies(tc.strategies))
+		if result.SanitizedText != tc.want {
+			t.Errorf("strategies %v:\n got %q\nwant %q", tc.strategies, result.SanitizedText, tc.want)
+		}
+	}
+}
+
+func TestRedact_StrategyMappings(t *testing.T) {
+	text := "Thomas Schmidt, Tel. 030 1234567"
+	entities := scanner.DefaultScanner(nil).Scan(text)
+
+	result := Redact(text, entities, WithStrategies(map[string]Strategy{"PHONE": MaskStrategy{}}))
+	byType := make(map[string]Mapping)
+	for _, m := range result.Mappings {
+		byType[m.Type] = m
+	}
+	if m := byType["PERSON"]; m.Strategy != "token" || !m.Reversible || !m.Restorable() {
+		t.Errorf("PERSON mapping = %+v, want a reversible token", m)
+	}
+	if m := byType["PHONE"]; m.Token != "***" || m.Strategy != "mask" || m.Reversible || m.Restorable() {
+		t.Errorf("PHONE mapping = %+v, want an irreversible mask", m)
+	}
+}
+
+func TestParseStrategies(t *testing.T) {
+	got, err := ParseStrategies(map[string]string{"EMAIL": "partial", "PERSON": "label"})
+	if err != nil {
+		t.Fatalf("ParseStrategies: %v", err)
+	}
+	if got["EMAIL"].Name() != "partial" || got["PERSON"].Name() != "label" {
+		t.Errorf("ParseStrategies = %v", got)
+	}
+	if _, err := ParseStrategies(map[string]string{"EMAIL": "hash"}); err == nil {
+		t.Error("unknown strategy accepted")
+	}
+	if _, err := ParseStrategies(map[string]string{"email": "mask"}); err == nil {
+		t.Error("lower-case type accepted")
+	}
+}
+
+func TestMaskKeepLast(t *testing.T) {
+	cases := map[string]string{
+		"DE89 3704 0044 0532 0130 00": "**** **** **** **** **30 00",
+		"030 1234567":                 "*** ***4567",
+		"12345":                       "***",
+	}
+	for in, want := range cases {
+		if got := maskKeepLast(in, 4); got != want {
+			t.Errorf("maskKeepLast(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
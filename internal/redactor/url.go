@@ -0,0 +1,33 @@
+package redactor
+
+import "fmt"
+
+// URLMode decides how URL entities are redacted.
+type URLMode string
+
+const (
+	// URLToken replaces the whole URL with a [URL_n] token (the default).
+	URLToken URLMode = "token"
+	// URLComponents keeps the scheme, host and path and replaces only the
+	// sensitive components found by the scanner (scanner.Entity.Parts):
+	// "https://intranet.firma.de/docs?user=thomas.schmidt&token=abc" becomes
+	// "https://intranet.firma.de/docs?user=[PERSON_1]&token=[SECRET_1]".
+	URLComponents URLMode = "components"
+)
+
+// WithURLRedaction sets how URLs are redacted.
+func WithURLRedaction(mode URLMode) Option {
+	return func(o *options) { o.urlMode = mode }
+}
+
+// ParseURLMode converts a mode read from a config file, flag or request.
+// The empty string selects URLToken.
+func ParseURLMode(s string) (URLMode, error) {
+	switch m := URLMode(s); m {
+	case "":
+		return URLToken, nil
+	case URLToken, URLComponents:
+		return m, nil
+	}
+	return "", fmt.Errorf("unknown URL mode %q (want token|components)", s)
+}
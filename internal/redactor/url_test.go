@@ -0,0 +1,34 @@
+package redactor
+
+import (
+	"testing"
+
+	"github.com/svenplb/aegis-core/internal/scanner"
+)
+
+func TestRedact_URLComponents(t *testing.T) {
+	text := "Link: https://intranet.firma.de/docs?user=thomas.schmidt&token=abc and https://example.org/about"
+	entities := scanner.DefaultScanner(nil).Scan(text)
+
+	result := Redact(text, entities, WithURLRedaction(URLComponents))
+
+	want := "Link: https://intranet.firma.de/docs?user=[PERSON_1]&token=[SECRET_1] and https://example.org/about"
+	if result.SanitizedText != want {
+		t.Errorf("SanitizedText = %q, want %q", result.SanitizedText, want)
+	}
+	if len(result.Mappings) != 2 || result.Mappings[0].Original != "thomas.schmidt" || result.Mappings[1].Original != "abc" {
+		t.Errorf("Mappings = %v, want the token and the user", result.Mappings)
+	}
+}
+
+func TestParseURLMode(t *testing.T) {
+	if m, err := ParseURLMode(""); err != nil || m != URLToken {
+		t.Errorf(`ParseURLMode("") = %q, %v`, m, err)
+	}
+	if m, err := ParseURLMode("components"); err != nil || m != URLComponents {
+		t.Errorf(`ParseURLMode("components") = %q, %v`, m, err)
+	}
+	if _, err := ParseURLMode("path"); err == nil {
+		t.Error(`ParseURLMode("path") succeeded`)
+	}
+}
@@ -1,6 +1,8 @@
 package restorer
 
 import (
+	"regexp"
+	"slices"
 	"sort"
 	"strings"
 
@@ -9,39 +11,113 @@ import (
 
 // Restore replaces every placeholder token in text with its original value.
 // Tokens are replaced longest-first to avoid partial matches
-// (e.g. [PERSON_10] is replaced before [PERSON_1]).
+// (e.g. [PERSON_10] is replaced before [PERSON_1]). Mappings of
+// irreversible strategies (masks, labels) are skipped.
+//
+// A token with a mapping per occurrence ("Thomas Schmidt", then "Schmidt")
+// is restored to those originals in order; further occurrences get the
+// full value.
 func Restore(text string, mappings []redactor.Mapping) string {
 	if len(mappings) == 0 {
 		return text
 	}
+	return newReplacer(mappings).replace(text)
+}
 
-	sorted := make([]redactor.Mapping, len(mappings))
-	copy(sorted, mappings)
-	sort.Slice(sorted, func(i, j int) bool {
-		return len(sorted[i].Token) > len(sorted[j].Token)
-	})
+// tokenRe matches anything that looks like a placeholder token.
+var tokenRe = regexp.MustCompile(`\[[^\[\]\s]+\]`)
 
-	for _, m := range sorted {
-		text = strings.ReplaceAll(text, m.Token, m.Original)
-	}
-	return text
+// RestoreWith replaces every token in text for which lookup returns an
+// original, such as the pseudonyms held in a vault. Other bracketed text
+// is left alone.
+func RestoreWith(text string, lookup func(token string) (string, bool)) string {
+	return tokenRe.ReplaceAllStringFunc(text, func(token string) string {
+		if original, ok := lookup(token); ok {
+			return original
+		}
+		return token
+	})
 }
 
 // StreamRestorer incrementally restores tokens from streaming chunks.
 // It buffers incomplete tokens (an opening '[' without a matching ']').
 type StreamRestorer struct {
-	mappings []redactor.Mapping
+	replacer *replacer
 	buffer   string
 }
 
 // NewStreamRestorer returns a StreamRestorer configured with the given mappings.
 func NewStreamRestorer(mappings []redactor.Mapping) *StreamRestorer {
-	sorted := make([]redactor.Mapping, len(mappings))
-	copy(sorted, mappings)
-	sort.Slice(sorted, func(i, j int) bool {
-		return len(sorted[i].Token) > len(sorted[j].Token)
+	return &StreamRestorer{replacer: newReplacer(mappings)}
+}
+
+// replacer restores the tokens of a set of mappings, counting the
+// occurrences of tokens that stand for several surface forms.
+type replacer struct {
+	tokens    []string            // longest first
+	originals map[string][]string // per token, one per occurrence if they differ
+	full      map[string]string   // value for occurrences beyond originals
+	used      map[string]int
+}
+
+// newReplacer returns a replacer for the restorable mappings.
+func newReplacer(mappings []redactor.Mapping) *replacer {
+	r := &replacer{
+		originals: make(map[string][]string),
+		full:      make(map[string]string),
+		used:      make(map[string]int),
+	}
+	for _, m := range mappings {
+		if !m.Restorable() {
+			continue
+		}
+		if _, ok := r.originals[m.Token]; !ok {
+			r.tokens = append(r.tokens, m.Token)
+			r.full[m.Token] = m.Original
+		}
+		if m.Canonical != "" {
+			r.full[m.Token] = m.Canonical
+		}
+		r.originals[m.Token] = append(r.originals[m.Token], m.Original)
+	}
+	for tok, originals := range r.originals {
+		if !slices.ContainsFunc(originals, func(o string) bool { return o != originals[0] }) {
+			r.originals[tok] = originals[:1]
+		}
+	}
+	sort.SliceStable(r.tokens, func(i, j int) bool {
+		return len(r.tokens[i]) > len(r.tokens[j])
 	})
-	return &StreamRestorer{mappings: sorted}
+	return r
+}
+
+// replace restores the tokens in text.
+func (r *replacer) replace(text string) string {
+	for _, tok := range r.tokens {
+		originals := r.originals[tok]
+		if len(originals) == 1 {
+			text = strings.ReplaceAll(text, tok, originals[0])
+			continue
+		}
+		var b strings.Builder
+		for {
+			i := strings.Index(text, tok)
+			if i < 0 {
+				break
+			}
+			b.WriteString(text[:i])
+			if n := r.used[tok]; n < len(originals) {
+				b.WriteString(originals[n])
+			} else {
+				b.WriteString(r.full[tok])
+			}
+			r.used[tok]++
+			text = text[i+len(tok):]
+		}
+		b.WriteString(text)
+		text = b.String()
+	}
+	return text
 }
 
 // Process accepts the next chunk of streamed text. It returns any text that
@@ -72,8 +148,5 @@ func (sr *StreamRestorer) Flush() string {
 }
 
 func (sr *StreamRestorer) replaceMappings(text string) string {
-	for _, m := range sr.mappings {
-		text = strings.ReplaceAll(text, m.Token, m.Original)
-	}
-	return text
+	return sr.replacer.replace(text)
 }
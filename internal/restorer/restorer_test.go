@@ -57,6 +57,61 @@ func TestRestore_EmptyMappings(t *testing.T) {
 	}
 }
 
+func TestRestore_SkipsIrreversible(t *testing.T) {
+	text := "[PERSON_1] paid with ***, card ending 1881."
+	mappings := []redactor.Mapping{
+		{Token: "[PERSON_1]", Original: "Alice", Type: "PERSON", Strategy: "token", Reversible: true},
+		{Token: "***", Original: "4012 8888 8888 1881", Type: "CREDIT_CARD", Strategy: "mask"},
+	}
+
+	got := Restore(text, mappings)
+	want := "Alice paid with ***, card ending 1881."
+	if got != want {
+		t.Errorf("Restore = %q, want %q", got, want)
+	}
+}
+
+func TestRestoreWith(t *testing.T) {
+	vault := map[string]string{"[PERSON_k1_7f3a9c]": "Alice"}
+	lookup := func(token string) (string, bool) {
+		original, ok := vault[token]
+		return original, ok
+	}
+
+	got := RestoreWith("[PERSON_k1_7f3a9c] met [PERSON_k1_000000] [sic].", lookup)
+	want := "Alice met [PERSON_k1_000000] [sic]."
+	if got != want {
+		t.Errorf("RestoreWith = %q, want %q", got, want)
+	}
+}
+
+func TestRoundTrip_PartialMention(t *testing.T) {
+	text := "Thomas Schmidt rief an. Herrn Schmidt bitte zurückrufen."
+	entities := []scanner.Entity{
+		{Start: 0, End: 14, Type: "PERSON", Text: "Thomas Schmidt", Score: 0.85, Detector: "gazetteer"},
+		{Start: 30, End: 37, Type: "PERSON", Text: "Schmidt", Score: 0.77, Detector: "coreference", Canonical: "Thomas Schmidt"},
+	}
+
+	result := redactor.Redact(text, entities)
+	if want := "[PERSON_1] rief an. Herrn [PERSON_1] bitte zurückrufen."; result.SanitizedText != want {
+		t.Fatalf("SanitizedText = %q, want %q", result.SanitizedText, want)
+	}
+	if got := Restore(result.SanitizedText, result.Mappings); got != text {
+		t.Errorf("round trip = %q, want %q", got, text)
+	}
+
+	sr := NewStreamRestorer(result.Mappings)
+	got := sr.Process("[PERSON_1] rief an. Herrn [PERS") + sr.Process("ON_1] bitte zurückrufen.") + sr.Flush()
+	if got != text {
+		t.Errorf("stream round trip = %q, want %q", got, text)
+	}
+
+	// Occurrences beyond the redacted text get the full name.
+	if got, want := Restore("[PERSON_1], [PERSON_1], [PERSON_1]", result.Mappings), "Thomas Schmidt, Schmidt, Thomas Schmidt"; got != want {
+		t.Errorf("Restore = %q, want %q", got, want)
+	}
+}
+
 func TestRoundTrip(t *testing.T) {
 	original := "Alice met Bob at the park."
 	entities := []scanner.Entity{
@@ -0,0 +1,224 @@
+package scanner
+
+import (
+	"regexp"
+	"strings"
+)
+
+// --- ADDRESS: blocks and components ---
+
+// Address is the decomposition of an ADDRESS entity. Components hold the
+// text as written, except Country, which is the English country name
+// derived from the postal format or city when the text does not name one.
+type Address struct {
+	Street      string `json:"street,omitempty"`
+	HouseNumber string `json:"house_number,omitempty"`
+	// Unit is the apartment, suite or floor: "#4133", "Apt 2", "2/3" for
+	// the Austrian stair/door notation "5/2/3".
+	Unit     string `json:"unit,omitempty"`
+	Postcode string `json:"postcode,omitempty"`
+	City     string `json:"city,omitempty"`
+	// Region is the state, province or county: "CA", "Ontario".
+	Region  string `json:"region,omitempty"`
+	Country string `json:"country,omitempty"`
+}
+
+// addressGapRe matches what may separate two fragments of one address:
+// commas and spaces, with at most one line break.
+var addressGapRe = regexp.MustCompile(`^[ \t,]*(?:\r?\n[ \t,]*)?$`)
+
+// mergeAddresses joins ADDRESS entities that follow each other with only a
+// comma, space or single line break between them into one address block,
+// together with a LOCATION (city or country) right after them: a street
+// line, a postcode and city line and a country line become one entity.
+// entities must be sorted by Start.
+func mergeAddresses(text string, entities []Entity) []Entity {
+	merged := make([]Entity, 0, len(entities))
+	for _, e := range entities {
+		if n := len(merged); n > 0 && (e.Type == "ADDRESS" || e.Type == "LOCATION") {
+			prev := &merged[n-1]
+			if prev.Type == "ADDRESS" && e.Start >= prev.End && addressGapRe.MatchString(text[prev.End:e.Start]) {
+				prev.End = e.End
+				prev.Text = text[prev.Start:prev.End]
+				prev.Score = max(prev.Score, e.Score)
+				prev.Deobfuscated = prev.Deobfuscated || e.Deobfuscated
+				continue
+			}
+		}
+		merged = append(merged, e)
+	}
+	return merged
+}
+
+// parseAddresses sets Address on every ADDRESS entity.
+func parseAddresses(entities []Entity) []Entity {
+	for i, e := range entities {
+		if e.Type == "ADDRESS" {
+			entities[i].Address = parseAddress(e.Text)
+		}
+	}
+	return entities
+}
+
+const (
+	usStates = `AL|AK|AZ|AR|CA|CO|CT|DE|FL|GA|HI|ID|IL|IN|IA|KS|KY|LA|ME|MD|MA|MI|MN|MS|MO|MT|NE|NV|NH|NJ|NM|NY|NC|ND|OH|OK|OR|PA|RI|SC|SD|TN|TX|UT|VT|VA|WA|WV|WI|WY|DC|` +
+		`Alabama|Alaska|Arizona|Arkansas|California|Colorado|Connecticut|Delaware|Florida|Georgia|Hawaii|Idaho|Illinois|Indiana|Iowa|Kansas|Kentucky|Louisiana|Maine|Maryland|Massachusetts|Michigan|Minnesota|Mississippi|Missouri|Montana|Nebraska|Nevada|New Hampshire|New Jersey|New Mexico|New York|North Carolina|North Dakota|Ohio|Oklahoma|Oregon|Pennsylvania|Rhode Island|South Carolina|South Dakota|Tennessee|Texas|Utah|Vermont|Virginia|Washington|West Virginia|Wisconsin|Wyoming|District of Columbia`
+	caProvinces = `AB|BC|MB|NB|NL|NS|NT|NU|ON|PE|QC|SK|YT`
+)
+
+// Address lines, each matched against one comma- or line-separated part.
+var (
+	// "10115 Berlin", "1100 Wien", "00-950 Warszawa", "1012 AB Amsterdam"
+	postcodeCityRe = regexp.MustCompile(`^(\d{4,5}|\d{2}-\d{3}|\d{4} ?[A-Z]{2})[ \t]+(\p{Lu}.*)$`)
+	// "CA 91723", "California 91723-1234"
+	usStateZipRe = regexp.MustCompile(`^(` + usStates + `)[ \t]+(\d{5}(?:-\d{4})?)$`)
+	// "ON K1A 0B1", "Ottawa ON K1A 0B1"
+	caPostcodeRe = regexp.MustCompile(`^(?:(.+?)[ \t]+)?(?:(` + caProvinces + `)[ \t]+)?([A-Z]\d[A-Z] ?\d[A-Z]\d)$`)
+	// "London SW1A 2AA", "SW1A 2AA"
+	ukPostcodeRe = regexp.MustCompile(`^(?:(.+?)[ \t]+)?([A-Z]{1,2}\d[0-9A-Z]? ?\d[A-Z]{2})$`)
+	// "D02 AX07", "Cork T12 AB34"
+	eircodeRe = regexp.MustCompile(`^(?:(.+?)[ \t]+)?([ACDEFHKNPRTVWXY]\d[0-9W] [A-Z0-9]{4})$`)
+	// "Dublin 2", "Dublin 6W"
+	dublinDistrictRe = regexp.MustCompile(`^(Dublin)[ \t]+(\d{1,2}|6W)$`)
+	// "440 N Barranca Ave #4133", "42, rue de la Loi"
+	numberFirstRe = regexp.MustCompile(`^(\d{1,5}[a-zA-Z]?)[ \t]+(\D.*)$`)
+	// "Gartenstraße 27", "Musterstraße 5/2/3", "strada Lipscani nr. 5"
+	numberLastRe = regexp.MustCompile(`^(.*?\D)[ \t]+(?:nr\.[ \t]?)?(\d{1,4}[a-zA-Z]?)(?:/(\d{1,4}(?:/\d{1,4})*))?$`)
+	// "#4133", "Apt 2", "Suite 100" at the end of a street
+	unitRe = regexp.MustCompile(`[ \t]+((?:#|Apt\.?|Suite|Ste\.?|Unit|Fl\.?)[ \t]*[A-Za-z0-9]+)$`)
+)
+
+// postcodeFormats are postcodes that may follow the city on the same line,
+// with the country they imply. region marks formats with a province
+// between city and postcode.
+var postcodeFormats = []struct {
+	re      *regexp.Regexp
+	country string
+	region  bool
+}{
+	{eircodeRe, "IE", false},
+	{caPostcodeRe, "CA", true},
+	{ukPostcodeRe, "GB", false},
+}
+
+// streetWordRe recognizes a street line without a house number by its
+// street type: "Fenian St", "Baker Street", "Rue de Rivoli".
+var streetWordRe = regexp.MustCompile(`(?i)(?:straße|strasse|str\.|weg|platz|allee|gasse|ring|damm|gürtel|markt|\b(?:st|street|ave|avenue|road|rd|lane|ln|drive|dr|blvd|boulevard|way|court|ct|place|pl|row|square|terrace)\.?)$|^(?:rue|via|calle|avenida|rua|ul\.|ulica|strada)\b`)
+
+// parseAddress decomposes an address block into its components. Each line
+// or comma-separated part is read as a postal line (postcode, city, region),
+// a street line, or a place name from the gazetteer. Other parts are taken
+// as the street if they come first and have a house number or street type,
+// and as the city otherwise.
+func parseAddress(text string) *Address {
+	a := &Address{}
+	impliedCountry := ""
+	parts := strings.FieldsFunc(text, func(r rune) bool { return r == '\n' || r == ',' })
+	for i, part := range parts {
+		part = strings.TrimSpace(part)
+		switch {
+		case part == "":
+		case i == 0 && len(parts) > 1 && isHouseNumber(part):
+			// "42, rue de la Loi"
+			a.HouseNumber = part
+		case a.parsePostal(part, &impliedCountry):
+		default:
+			loc, ok := lookupLocation(part)
+			switch {
+			case ok && loc.kind == "country":
+				a.Country = part
+			case ok && loc.kind == "region":
+				a.Region = part
+			case ok && a.City != "":
+				a.Region = part
+			case ok:
+				a.City = part
+			case a.Street == "" && a.Postcode == "" && a.City == "" && (strings.ContainsAny(part, "0123456789") || streetWordRe.MatchString(part)):
+				a.parseStreet(part)
+			case a.City == "":
+				a.City = part
+			}
+		}
+	}
+	if a.Country == "" {
+		code := impliedCountry
+		if loc, ok := lookupLocation(a.City); ok && code == "" {
+			code = loc.country
+		}
+		a.Country = loadLocations().countryNames[code]
+	}
+	return a
+}
+
+// parsePostal reads a postal line: postcode and city, US state and ZIP,
+// Canadian, UK and Irish postcodes. It records the country a postal format
+// implies in country and reports whether part was a postal line.
+func (a *Address) parsePostal(part string, country *string) bool {
+	if m := postcodeCityRe.FindStringSubmatch(part); m != nil {
+		a.Postcode, a.City = m[1], m[2]
+		return true
+	}
+	if m := usStateZipRe.FindStringSubmatch(part); m != nil {
+		a.Region, a.Postcode = m[1], m[2]
+		*country = "US"
+		return true
+	}
+	if m := dublinDistrictRe.FindStringSubmatch(part); m != nil {
+		// An Eircode on the same address takes precedence as postcode.
+		a.City = m[1]
+		if a.Postcode == "" {
+			a.Postcode = part
+		}
+		*country = "IE"
+		return true
+	}
+	for _, f := range postcodeFormats {
+		if m := f.re.FindStringSubmatch(part); m != nil {
+			if m[1] != "" && a.City == "" {
+				a.City = m[1]
+			}
+			if f.region && m[2] != "" {
+				a.Region = m[2]
+			}
+			a.Postcode = m[len(m)-1]
+			*country = f.country
+			return true
+		}
+	}
+	return false
+}
+
+// parseStreet splits a street line into street, house number and unit.
+func (a *Address) parseStreet(line string) {
+	if m := unitRe.FindStringSubmatchIndex(line); m != nil {
+		a.Unit = line[m[2]:m[3]]
+		line = line[:m[0]]
+	}
+	if m := numberFirstRe.FindStringSubmatch(line); m != nil {
+		a.HouseNumber, a.Street = m[1], strings.TrimSpace(m[2])
+		return
+	}
+	if m := numberLastRe.FindStringSubmatch(line); m != nil {
+		a.Street, a.HouseNumber = strings.TrimSpace(m[1]), m[2]
+		if m[3] != "" {
+			a.Unit = m[3]
+		}
+		return
+	}
+	a.Street = line
+}
+
+// isHouseNumber reports whether s is a bare house number such as "42" or "7a".
+func isHouseNumber(s string) bool {
+	digits := strings.TrimRight(s, "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ")
+	return digits != "" && len(digits) <= 5 && len(s)-len(digits) <= 1 && strings.Trim(digits, "0123456789") == ""
+}
+
+// lookupLocation looks a place name up in the gazetteer.
+func lookupLocation(name string) (location, bool) {
+	if name == "" {
+		return location{}, false
+	}
+	loc, ok := loadLocations().byKey[nameKey(name)]
+	return loc, ok
+}
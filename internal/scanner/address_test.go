@@ -0,0 +1,66 @@
+package scanner
+
+import "testing"
+
+func TestParseAddress(t *testing.T) {
+	cases := []struct {
+		text string
+		want Address
+	}{
+		{"Musterstraße 5/2/3\n1100 Wien\nAustria",
+			Address{Street: "Musterstraße", HouseNumber: "5", Unit: "2/3", Postcode: "1100", City: "Wien", Country: "Austria"}},
+		{"Gartenstraße 27, 10115 Berlin",
+			Address{Street: "Gartenstraße", HouseNumber: "27", Postcode: "10115", City: "Berlin", Country: "Germany"}},
+		{"440 N Barranca Ave #4133\nCovina, CA 91723",
+			Address{Street: "N Barranca Ave", HouseNumber: "440", Unit: "#4133", Postcode: "91723", City: "Covina", Region: "CA", Country: "United States"}},
+		{"42, rue de la Loi",
+			Address{Street: "rue de la Loi", HouseNumber: "42"}},
+		{"Fenian St\nDublin 2, D02 AX07\nIreland",
+			Address{Street: "Fenian St", Postcode: "D02 AX07", City: "Dublin", Country: "Ireland"}},
+		{"10 Downing Street\nLondon SW1A 2AA",
+			Address{Street: "Downing Street", HouseNumber: "10", Postcode: "SW1A 2AA", City: "London", Country: "United Kingdom"}},
+		{"strada Lipscani nr. 5",
+			Address{Street: "strada Lipscani", HouseNumber: "5"}},
+	}
+	for _, tc := range cases {
+		got := parseAddress(tc.text)
+		if *got != tc.want {
+			t.Errorf("parseAddress(%q) = %+v, want %+v", tc.text, *got, tc.want)
+		}
+	}
+}
+
+func TestAddress_MergedBlock(t *testing.T) {
+	s := DefaultScanner(nil)
+	input := "Rechnung an:\nGartenstraße 27\n10115 Berlin\nDeutschland\n\nVielen Dank."
+	var addresses []Entity
+	for _, e := range s.Scan(input) {
+		if e.Type == "ADDRESS" {
+			addresses = append(addresses, e)
+		}
+	}
+	if len(addresses) != 1 {
+		t.Fatalf("got %d ADDRESS entities, want one block: %v", len(addresses), addresses)
+	}
+	a := addresses[0]
+	if want := "Gartenstraße 27\n10115 Berlin\nDeutschland"; a.Text != want {
+		t.Errorf("Text = %q, want %q", a.Text, want)
+	}
+	if a.Address == nil || a.Address.City != "Berlin" || a.Address.Country != "Deutschland" || a.Address.HouseNumber != "27" {
+		t.Errorf("Address = %+v", a.Address)
+	}
+}
+
+func TestAddress_SeparateBlocksNotMerged(t *testing.T) {
+	s := DefaultScanner(nil)
+	input := "Von: Gartenstraße 27, 10115 Berlin\n\nAn: Musterstraße 5, 1100 Wien"
+	n := 0
+	for _, e := range s.Scan(input) {
+		if e.Type == "ADDRESS" {
+			n++
+		}
+	}
+	if n != 2 {
+		t.Errorf("got %d ADDRESS entities, want 2", n)
+	}
+}
@@ -307,13 +307,17 @@ func TestAdversarial_ZeroWidthChars(t *testing.T) {
 		t.Run("email with "+zwc.name, func(t *testing.T) {
 			input := "te" + zwc.char + "st@example.com"
 			entities := s.Scan(input)
-			t.Logf("email with %s: entities=%v", zwc.name, entities)
+			if !hasEntityWithText(entities, "EMAIL", input) {
+				t.Errorf("email with %s not detected over its original span: %v", zwc.name, entities)
+			}
 		})
 
 		t.Run("IBAN with "+zwc.name, func(t *testing.T) {
 			input := "DE89" + zwc.char + "370400440532013000"
 			entities := s.Scan(input)
-			t.Logf("IBAN with %s: entities=%v", zwc.name, entities)
+			if !hasEntityWithText(entities, "IBAN", input) {
+				t.Errorf("IBAN with %s not detected over its original span: %v", zwc.name, entities)
+			}
 		})
 	}
 
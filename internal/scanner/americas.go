@@ -0,0 +1,293 @@
+package scanner
+
+import (
+	"regexp"
+	"strings"
+)
+
+// --- SSN / ID_NUMBER: the Americas ---
+
+// americasScanners detects national identifiers of Brazil, Mexico, Canada
+// and the United States. Personal identification numbers are SSN, company,
+// tax, passport and licence numbers ID_NUMBER, as for Europe; the Subtype
+// names the identifier. Triggers are in Portuguese, Spanish, English and
+// French. Identifiers with a check digit are validated, and standalone
+// matches require a distinctive format.
+func americasScanners() []Scanner {
+	return []Scanner{
+		// BR: CPF (context-triggered): 529.982.247-25 or 52998224725
+		NewRegexScanner(
+			regexp.MustCompile(`(?i:\bCPF|Cadastro\s+de\s+Pessoas?\s+F[íi]sicas?)(?:\s+n[º°o]\.?)?[:\s]+(\d{3}\.?\d{3}\.?\d{3}-?\d{2})\b`),
+			"SSN", 0.95,
+			WithExtractGroup(1),
+			WithValidator(validateCPF),
+			WithSubtype("cpf"),
+		),
+		// BR: CPF standalone, formatted only
+		NewRegexScanner(
+			regexp.MustCompile(`\b\d{3}\.\d{3}\.\d{3}-\d{2}\b`),
+			"SSN", 0.90,
+			WithValidator(validateCPF),
+			WithSubtype("cpf"),
+		),
+		// BR: CNPJ (context-triggered): 11.222.333/0001-81
+		NewRegexScanner(
+			regexp.MustCompile(`(?i:\bCNPJ|Cadastro\s+Nacional\s+d[ae]\s+Pessoa\s+Jur[íi]dica)(?:\s+n[º°o]\.?)?[:\s]+(\d{2}\.?\d{3}\.?\d{3}/?\d{4}-?\d{2})\b`),
+			"ID_NUMBER", 0.95,
+			WithExtractGroup(1),
+			WithValidator(validateCNPJ),
+			WithSubtype("cnpj"),
+		),
+		// BR: CNPJ standalone, formatted only
+		NewRegexScanner(
+			regexp.MustCompile(`\b\d{2}\.\d{3}\.\d{3}/\d{4}-\d{2}\b`),
+			"ID_NUMBER", 0.90,
+			WithValidator(validateCNPJ),
+			WithSubtype("cnpj"),
+		),
+		// MX: CURP (context-triggered): HEGG560427MVZRRL04
+		NewRegexScanner(
+			regexp.MustCompile(`(?i:\bCURP|Clave\s+[ÚU]nica\s+de\s+Registro\s+de\s+Poblaci[óo]n)[:\s]+(`+curpPattern+`)\b`),
+			"SSN", 0.95,
+			WithExtractGroup(1),
+			WithValidator(validateCURP),
+			WithSubtype("curp"),
+		),
+		// MX: CURP standalone
+		NewRegexScanner(
+			regexp.MustCompile(`\b`+curpPattern+`\b`),
+			"SSN", 0.90,
+			WithValidator(validateCURP),
+			WithSubtype("curp"),
+		),
+		// MX: RFC (context-triggered): GODE561231GR8 (person), ABC680524P73 (company)
+		NewRegexScanner(
+			regexp.MustCompile(`(?i:\bRFC|Registro\s+Federal\s+de\s+Contribuyentes)[:\s]+([A-ZÑ&]{3,4}\d{6}[A-Z\d]{2}[\dA])\b`),
+			"ID_NUMBER", 0.95,
+			WithExtractGroup(1),
+			WithValidator(validateRFC),
+			WithSubtype("rfc"),
+		),
+		// CA: Social Insurance Number (context-triggered): 046 454 286
+		NewRegexScanner(
+			regexp.MustCompile(`(?:\bSIN\b|\bNAS\b|(?i:Social\s+Insurance\s+Number|num[ée]ro\s+d['’]assurance\s+sociale))(?:\s+(?i:No\.?|#))?[:\s]+(\d{3}[ \-]?\d{3}[ \-]?\d{3})\b`),
+			"SSN", 0.95,
+			WithExtractGroup(1),
+			WithValidator(validateSIN),
+			WithSubtype("sin"),
+		),
+		// US: ITIN: 9XX-7X-XXXX (the SSN pattern excludes the 9XX area)
+		NewRegexScanner(
+			regexp.MustCompile(`\b9\d{2}-\d{2}-\d{4}\b`),
+			"SSN", 0.90,
+			WithValidator(validateITIN),
+			WithSubtype("itin"),
+		),
+		// US: ITIN (context-triggered), also without dashes
+		NewRegexScanner(
+			regexp.MustCompile(`(?:\bITIN\b|(?i:Individual\s+Taxpayer\s+Identification\s+Number|N[úu]mero\s+de\s+Identificaci[óo]n\s+Personal\s+del\s+Contribuyente))[:\s#]+(9\d{2}-?\d{2}-?\d{4})\b`),
+			"SSN", 0.95,
+			WithExtractGroup(1),
+			WithValidator(validateITIN),
+			WithSubtype("itin"),
+		),
+		// US: EIN (context-triggered): 12-3456789
+		NewRegexScanner(
+			regexp.MustCompile(`(?:\bF?EIN\b|(?i:Employer\s+Identification\s+Number|Federal\s+Tax\s+ID(?:\s+Number)?|N[úu]mero\s+de\s+Identificaci[óo]n\s+(?:del\s+)?Empleador))[:\s#]+(\d{2}-?\d{7})\b`),
+			"ID_NUMBER", 0.90,
+			WithExtractGroup(1),
+			WithValidator(validateEIN),
+			WithSubtype("ein"),
+		),
+		// US: passport (context-triggered): 9 digits, or a letter and 8 digits
+		NewRegexScanner(
+			regexp.MustCompile(`(?i:(?:U\.?S\.?\s+)?passport|passeport|pasaporte|passaporte)(?i:\s+(?:No\.?|Number|n[º°o]\.?|n[úu]mero|num[ée]ro))?[:\s#]+([A-Z]\d{8}|\d{9})\b`),
+			"ID_NUMBER", 0.90,
+			WithExtractGroup(1),
+			WithSubtype("passport"),
+		),
+		// US: state driver's licence (context-triggered), optional state code
+		NewRegexScanner(
+			regexp.MustCompile(`(?:\bDL\b|(?i:driver['’]?s?\s+licen[cs]e|licencia\s+de\s+conduci[rg]|permis\s+de\s+conduire))(?i:\s+(?:No\.?|Number|#|n[úu]mero))?[:\s#]+(?:\(?[A-Z]{2}\)?[ \t]+)?([A-Z]{0,2}\d[\d \-]{4,16}\d)\b`),
+			"ID_NUMBER", 0.85,
+			WithExtractGroup(1),
+			WithValidator(validateUSDriverLicense),
+			WithSubtype("drivers_license"),
+		),
+	}
+}
+
+// curpPattern matches the structure of a Mexican CURP: four letters of the
+// name, birth date, sex (H/M/X), state, three internal consonants, a
+// homonym character and the check digit.
+const curpPattern = `[A-Z][AEIOUX][A-Z]{2}\d{2}(?:0[1-9]|1[0-2])(?:0[1-9]|[12]\d|3[01])[HMX][A-Z]{2}[B-DF-HJ-NP-TV-Z]{3}[A-Z\d]\d`
+
+// digitValues returns the ASCII digits of s as integers.
+func digitValues(s string) []int {
+	var digits []int
+	for _, r := range s {
+		if r >= '0' && r <= '9' {
+			digits = append(digits, int(r-'0'))
+		}
+	}
+	return digits
+}
+
+// allSame reports whether every digit equals the first; such numbers
+// (000.000.000-00, 111…) pass most mod-11 checks but are never issued.
+func allSame(digits []int) bool {
+	for _, d := range digits {
+		if d != digits[0] {
+			return false
+		}
+	}
+	return true
+}
+
+// mod11CheckDigit returns the Brazilian mod-11 check digit of digits for
+// the given weights: 0 if the remainder is below 2, else 11 minus it.
+func mod11CheckDigit(digits, weights []int) int {
+	sum := 0
+	for i, w := range weights {
+		sum += digits[i] * w
+	}
+	if r := sum % 11; r >= 2 {
+		return 11 - r
+	}
+	return 0
+}
+
+// validateCPF checks both mod-11 check digits of a Brazilian CPF.
+func validateCPF(s string) bool {
+	d := digitValues(s)
+	if len(d) != 11 || allSame(d) {
+		return false
+	}
+	return mod11CheckDigit(d, []int{10, 9, 8, 7, 6, 5, 4, 3, 2}) == d[9] &&
+		mod11CheckDigit(d, []int{11, 10, 9, 8, 7, 6, 5, 4, 3, 2}) == d[10]
+}
+
+// validateCNPJ checks both mod-11 check digits of a Brazilian CNPJ.
+func validateCNPJ(s string) bool {
+	d := digitValues(s)
+	if len(d) != 14 || allSame(d) {
+		return false
+	}
+	return mod11CheckDigit(d, []int{5, 4, 3, 2, 9, 8, 7, 6, 5, 4, 3, 2}) == d[12] &&
+		mod11CheckDigit(d, []int{6, 5, 4, 3, 2, 9, 8, 7, 6, 5, 4, 3, 2}) == d[13]
+}
+
+// validateCURP checks the check digit of a Mexican CURP: the first 17
+// characters, valued by their position in curpAlphabet, are weighted 18
+// down to 2, and the check digit is 10 minus the sum modulo 10.
+func validateCURP(s string) bool {
+	const curpAlphabet = "0123456789ABCDEFGHIJKLMNÑOPQRSTUVWXYZ"
+	chars := []rune(s)
+	if len(chars) != 18 {
+		return false
+	}
+	alphabet := []rune(curpAlphabet)
+	sum := 0
+	for i, r := range chars[:17] {
+		v := indexRune(alphabet, r)
+		if v < 0 {
+			return false
+		}
+		sum += v * (18 - i)
+	}
+	return int(chars[17]-'0') == (10-sum%10)%10
+}
+
+// validateRFC checks the check character of a Mexican RFC. Company RFCs
+// (12 characters) are padded with a leading space; the first 12 characters,
+// valued by their position in rfcAlphabet, are weighted 13 down to 2, and
+// the check is 11 minus the sum modulo 11 ("A" for 10, "0" for 11).
+func validateRFC(s string) bool {
+	const rfcAlphabet = "0123456789ABCDEFGHIJKLMN&OPQRSTUVWXYZ Ñ"
+	chars := []rune(s)
+	if len(chars) == 12 {
+		chars = append([]rune{' '}, chars...)
+	}
+	if len(chars) != 13 {
+		return false
+	}
+	alphabet := []rune(rfcAlphabet)
+	sum := 0
+	for i, r := range chars[:12] {
+		v := indexRune(alphabet, r)
+		if v < 0 {
+			return false
+		}
+		sum += v * (13 - i)
+	}
+	want := '0'
+	switch r := sum % 11; {
+	case r == 1:
+		want = 'A'
+	case r > 1:
+		want = rune('0' + 11 - r)
+	}
+	return chars[12] == want
+}
+
+func indexRune(alphabet []rune, r rune) int {
+	for i, a := range alphabet {
+		if a == r {
+			return i
+		}
+	}
+	return -1
+}
+
+// validateSIN checks a Canadian Social Insurance Number: nine digits, not
+// starting with 0 or 8, passing the Luhn check.
+func validateSIN(s string) bool {
+	d := digitValues(s)
+	return len(d) == 9 && d[0] != 0 && d[0] != 8 && validateLuhn(s)
+}
+
+// validateITIN checks that the group digits of a US ITIN (9XX-GG-XXXX) are
+// in one of the ranges the IRS issues: 50–65, 70–88, 90–92 and 94–99.
+func validateITIN(s string) bool {
+	d := digitValues(s)
+	if len(d) != 9 || d[0] != 9 {
+		return false
+	}
+	g := d[3]*10 + d[4]
+	return (g >= 50 && g <= 65) || (g >= 70 && g <= 88) || (g >= 90 && g <= 92) || g >= 94
+}
+
+// unassignedEINPrefixes are the two-digit EIN prefixes the IRS has never
+// assigned to a campus.
+var unassignedEINPrefixes = map[string]bool{
+	"00": true, "07": true, "08": true, "09": true, "17": true, "18": true, "19": true,
+	"28": true, "29": true, "49": true, "69": true, "70": true, "78": true, "79": true,
+	"89": true, "96": true, "97": true,
+}
+
+// validateEIN checks that a US EIN uses an assigned prefix.
+func validateEIN(s string) bool {
+	return len(asciiDigits(s)) == 9 && !unassignedEINPrefixes[s[:2]]
+}
+
+// usDriverLicenseFormats are the driver's licence number formats of the
+// most populous US states, after removing spaces and dashes.
+var usDriverLicenseFormats = []*regexp.Regexp{
+	regexp.MustCompile(`^[A-Z]\d{7}$`),    // CA, NY (older)
+	regexp.MustCompile(`^[A-Z]\d{12}$`),   // FL, MI, MD, MN
+	regexp.MustCompile(`^[A-Z]\d{11}$`),   // IL
+	regexp.MustCompile(`^[A-Z]\d{14}$`),   // NJ
+	regexp.MustCompile(`^[A-Z]{2}\d{6}$`), // OH
+	regexp.MustCompile(`^\d{7,9}$`),       // TX, PA (8), NY, GA, NC (9), AZ
+}
+
+// validateUSDriverLicense checks a number against usDriverLicenseFormats.
+func validateUSDriverLicense(s string) bool {
+	s = strings.NewReplacer(" ", "", "-", "").Replace(s)
+	for _, re := range usDriverLicenseFormats {
+		if re.MatchString(s) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,80 @@
+package scanner
+
+import "testing"
+
+func TestAmericas_Detected(t *testing.T) {
+	s := DefaultScanner(nil)
+	cases := []struct {
+		name    string
+		input   string
+		typ     string
+		want    string
+		subtype string
+	}{
+		{"CPF formatted", "Cliente 529.982.247-25 cadastrado", "SSN", "529.982.247-25", "cpf"},
+		{"CPF with context", "CPF nº 52998224725", "SSN", "52998224725", "cpf"},
+		{"CNPJ formatted", "Fornecedor 11.222.333/0001-81", "ID_NUMBER", "11.222.333/0001-81", "cnpj"},
+		{"CNPJ with context", "CNPJ: 11222333000181", "ID_NUMBER", "11222333000181", "cnpj"},
+		{"CURP", "CURP: HEGG560427MVZRRL04", "SSN", "HEGG560427MVZRRL04", "curp"},
+		{"CURP standalone", "Registro HEGG560427MVZRRL04 validado", "SSN", "HEGG560427MVZRRL04", "curp"},
+		{"RFC person", "RFC: GODE561231GR8", "ID_NUMBER", "GODE561231GR8", "rfc"},
+		{"SIN", "SIN: 130 692 544", "SSN", "130 692 544", "sin"},
+		{"NAS (FR)", "Numéro d'assurance sociale : 130-692-544", "SSN", "130-692-544", "sin"},
+		{"ITIN", "Taxpayer 912-70-1234 filed", "SSN", "912-70-1234", "itin"},
+		{"EIN", "EIN: 12-3456789", "ID_NUMBER", "12-3456789", "ein"},
+		{"US passport", "U.S. Passport No. 123456789", "ID_NUMBER", "123456789", "passport"},
+		{"passport next-gen", "Pasaporte: A12345678", "ID_NUMBER", "A12345678", "passport"},
+		{"CA driver's licence", "Driver's License: (CA) A1234567", "ID_NUMBER", "A1234567", "drivers_license"},
+		{"FL driver's licence", "DL # S530-460-75-329-0", "ID_NUMBER", "S530-460-75-329-0", "drivers_license"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			for _, e := range s.Scan(tc.input) {
+				if e.Type == tc.typ && e.Text == tc.want {
+					if e.Subtype != tc.subtype {
+						t.Errorf("Subtype = %q, want %q", e.Subtype, tc.subtype)
+					}
+					return
+				}
+			}
+			t.Fatalf("%s %q not found in %q, got %v", tc.typ, tc.want, tc.input, s.Scan(tc.input))
+		})
+	}
+}
+
+func TestAmericas_ChecksumsRejected(t *testing.T) {
+	s := DefaultScanner(nil)
+	cases := []struct {
+		input string
+		typ   string
+	}{
+		{"Cliente 529.982.247-26 cadastrado", "SSN"},
+		{"Cliente 111.111.111-11 cadastrado", "SSN"},
+		{"Fornecedor 11.222.333/0001-82", "ID_NUMBER"},
+		{"CURP: HEGG560427MVZRRL05", "SSN"},
+		{"RFC: GODE561231GR9", "ID_NUMBER"},
+		{"SIN: 130 692 545", "SSN"},
+		{"Taxpayer 912-40-1234 filed", "SSN"},
+		{"EIN: 07-3456789", "ID_NUMBER"},
+	}
+	for _, tc := range cases {
+		if n := countEntitiesOfType(s.Scan(tc.input), tc.typ); n != 0 {
+			t.Errorf("invalid identifier flagged as %s in %q", tc.typ, tc.input)
+		}
+	}
+}
+
+func TestValidateRFC(t *testing.T) {
+	// 12-character RFCs of companies are padded with a space before the check.
+	cases := map[string]bool{
+		"GODE561231GR8": true,
+		"GODE561231GRA": false,
+		"ABC680524P73":  true,
+		"ABC680524P74":  false,
+	}
+	for s, want := range cases {
+		if got := validateRFC(s); got != want {
+			t.Errorf("validateRFC(%q) = %v, want %v", s, got, want)
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package scanner
+
+import (
+	"regexp"
+	"strings"
+)
+
+// --- FINANCIAL: structured amounts ---
+
+// Amount is the monetary value of a FINANCIAL entity.
+type Amount struct {
+	// Value is the amount as a decimal string with "." as the decimal
+	// separator and no grouping: "1500.00", "9500".
+	Value string `json:"value"`
+	// Currency is the ISO 4217 code, empty if the text does not name one
+	// unambiguously ("kr" may be SEK, NOK or DKK).
+	Currency string `json:"currency,omitempty"`
+}
+
+// currencySymbols maps currency symbols and local abbreviations to their
+// ISO 4217 code, longest first so that "US$" wins over "$".
+var currencySymbols = []struct{ symbol, code string }{
+	{"US$", "USD"}, {"CA$", "CAD"}, {"C$", "CAD"}, {"AU$", "AUD"}, {"A$", "AUD"},
+	{"NZ$", "NZD"}, {"HK$", "HKD"}, {"S$", "SGD"}, {"R$", "BRL"},
+	{"€", "EUR"}, {"$", "USD"}, {"£", "GBP"}, {"¥", "JPY"}, {"₹", "INR"},
+	{"zł", "PLN"}, {"Kč", "CZK"}, {"Ft", "HUF"}, {"lei", "RON"}, {"Fr.", "CHF"},
+}
+
+// currencyCodeRe finds an ISO 4217 code in an amount.
+var currencyCodeRe = regexp.MustCompile(`\b(?:EUR|USD|GBP|CHF|JPY|CNY|INR|AUD|CAD|NZD|SGD|HKD|BRL|MXN|PLN|CZK|HUF|RON|BGN|SEK|NOK|DKK|ISK|TRY|ZAR|AED|SAR|ILS)\b`)
+
+// amountNumberRe matches the number left after removing the currency:
+// digits with grouping and decimal separators, and an optional ",-" or ".–"
+// for whole amounts.
+var amountNumberRe = regexp.MustCompile(`^\d[\d.,'’ \x{00A0}\x{202F}]*?(?:[.,][-–])?$`)
+
+// parseAmount parses the amount in the text of a FINANCIAL entity. It
+// returns nil for text that is not an amount, such as a BIC or a SEPA
+// creditor ID.
+//
+// Spaces and apostrophes always group digits. Of "." and ",", the last one
+// is the decimal separator when both occur; when only one occurs it groups
+// digits if it occurs more than once or is followed by exactly three digits
+// ("1.500", "$1,500"), and is the decimal separator otherwise ("65,00").
+func parseAmount(text string) *Amount {
+	currency := ""
+	if code := currencyCodeRe.FindString(text); code != "" {
+		currency = code
+		text = strings.Replace(text, code, "", 1)
+	} else {
+		for _, cs := range currencySymbols {
+			if i := strings.Index(text, cs.symbol); i >= 0 {
+				currency = cs.code
+				text = text[:i] + text[i+len(cs.symbol):]
+				break
+			}
+		}
+	}
+	text = strings.TrimSpace(text)
+	if t, ok := strings.CutSuffix(text, "kr."); ok {
+		text = t
+	} else {
+		text = strings.TrimSuffix(text, "kr")
+	}
+	text = strings.TrimSpace(text)
+	if !amountNumberRe.MatchString(text) {
+		return nil
+	}
+	text = strings.TrimRight(text, "-–")
+	text = strings.TrimRight(text, ".,")
+
+	var digits strings.Builder
+	decimal := decimalSeparator(text)
+	for _, r := range text {
+		switch {
+		case r >= '0' && r <= '9':
+			digits.WriteRune(r)
+		case r == decimal:
+			digits.WriteByte('.')
+		}
+	}
+	return &Amount{Value: digits.String(), Currency: currency}
+}
+
+// decimalSeparator returns the decimal separator of a number, or 0 if it
+// has none. See parseAmount.
+func decimalSeparator(number string) rune {
+	lastDot, lastComma := strings.LastIndex(number, "."), strings.LastIndex(number, ",")
+	switch {
+	case lastDot >= 0 && lastComma >= 0:
+		if lastDot > lastComma {
+			return '.'
+		}
+		return ','
+	case lastDot < 0 && lastComma < 0:
+		return 0
+	}
+	sep, last := '.', lastDot
+	if lastComma >= 0 {
+		sep, last = ',', lastComma
+	}
+	if strings.Count(number, string(sep)) > 1 || len(number)-last-1 == 3 {
+		return 0
+	}
+	return sep
+}
+
+// parseAmounts sets Amount on every FINANCIAL entity whose text is an amount.
+func parseAmounts(entities []Entity) []Entity {
+	for i, e := range entities {
+		if e.Type == "FINANCIAL" {
+			entities[i].Amount = parseAmount(e.Text)
+		}
+	}
+	return entities
+}
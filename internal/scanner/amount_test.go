@@ -0,0 +1,87 @@
+package scanner
+
+import "testing"
+
+func TestParseAmount(t *testing.T) {
+	cases := []struct {
+		text     string
+		value    string
+		currency string
+	}{
+		{"1.500,00 €", "1500.00", "EUR"},
+		{"€1,000.00", "1000.00", "EUR"},
+		{"€9.500", "9500", "EUR"},
+		{"$2,500.00", "2500.00", "USD"},
+		{"£ 99.90", "99.90", "GBP"},
+		{"CHF 1'500.00", "1500.00", "CHF"},
+		{"CHF 1’500.00", "1500.00", "CHF"},
+		{"AUD 4,500.00", "4500.00", "AUD"},
+		{"15 000,00 Kč", "15000.00", "CZK"},
+		{"1 500 000 Ft", "1500000", "HUF"},
+		{"15.000,00 lei", "15000.00", "RON"},
+		{"1 500,00 zł", "1500.00", "PLN"},
+		{"15 000,00 kr", "15000.00", ""},
+		{"45000 SEK", "45000", "SEK"},
+		{"2.544,70", "2544.70", ""},
+		{"65,00", "65.00", ""},
+		{"1.500,- €", "1500", "EUR"},
+	}
+	for _, tc := range cases {
+		a := parseAmount(tc.text)
+		if a == nil {
+			t.Errorf("parseAmount(%q) = nil", tc.text)
+			continue
+		}
+		if a.Value != tc.value || a.Currency != tc.currency {
+			t.Errorf("parseAmount(%q) = %+v, want %s %s", tc.text, *a, tc.value, tc.currency)
+		}
+	}
+}
+
+func TestParseAmount_NotAnAmount(t *testing.T) {
+	for _, text := range []string{"GIBAATWWXXX", "BKAUATWW", "RF18539007547034", "DE98ZZZ09999999999"} {
+		if a := parseAmount(text); a != nil {
+			t.Errorf("parseAmount(%q) = %+v, want nil", text, *a)
+		}
+	}
+}
+
+func TestScan_FinancialAmount(t *testing.T) {
+	s := DefaultScanner(nil)
+	for _, e := range s.Scan("Gesamtbetrag: 1.500,00 € inkl. MwSt.") {
+		if e.Type == "FINANCIAL" {
+			if e.Amount == nil || e.Amount.Value != "1500.00" || e.Amount.Currency != "EUR" {
+				t.Errorf("Amount = %+v, want 1500.00 EUR", e.Amount)
+			}
+			return
+		}
+	}
+	t.Fatal("no FINANCIAL entity found")
+}
+
+func TestScan_FinancialAmountSpaceGrouped(t *testing.T) {
+	s := DefaultScanner(nil)
+	cases := []struct {
+		text, amount, value, currency string
+	}{
+		{"Celkem 15 000,00 Kč za služby.", "15 000,00 Kč", "15000.00", "CZK"},
+		{"Celkem 15 000,00 Kč.", "15 000,00 Kč", "15000.00", "CZK"},
+		{"Suma 1 500,00 zł brutto", "1 500,00 zł", "1500.00", "PLN"},
+		{"Összeg: 1 500 000 Ft", "1 500 000 Ft", "1500000", "HUF"},
+	}
+	for _, tc := range cases {
+		var found bool
+		for _, e := range s.Scan(tc.text) {
+			if e.Type != "FINANCIAL" {
+				continue
+			}
+			found = true
+			if e.Text != Normalize(tc.amount) || e.Amount == nil || e.Amount.Value != tc.value || e.Amount.Currency != tc.currency {
+				t.Errorf("%q: FINANCIAL %q, Amount = %+v, want %q = %s %s", tc.text, e.Text, e.Amount, tc.amount, tc.value, tc.currency)
+			}
+		}
+		if !found {
+			t.Errorf("%q: no FINANCIAL entity found", tc.text)
+		}
+	}
+}
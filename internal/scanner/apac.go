@@ -0,0 +1,383 @@
+package scanner
+
+import (
+	"regexp"
+	"strings"
+	"time"
+)
+
+// --- SSN / ID_NUMBER: Asia-Pacific and the Middle East ---
+
+// apacSep separates a trigger from the number. CJK text often has no space
+// and a full-width colon, so the separator may be empty.
+const apacSep = `[:：\s#№]*`
+
+// apacScanners detects national identifiers of India, China, Japan, Korea,
+// Singapore, Australia, the UAE, Saudi Arabia, Israel and Turkey. Personal
+// identification numbers are SSN, tax numbers ID_NUMBER and health insurance
+// numbers MEDICAL; the Subtype names the identifier.
+//
+// Triggers are given in English and the local script. Go's \b only knows
+// ASCII word characters, so it is used after Latin triggers but never before
+// a CJK, Arabic, Hebrew or Devanagari one, where it would fail to match.
+// Standalone matches require a distinctive format and a check digit.
+func apacScanners() []Scanner {
+	return []Scanner{
+		// IN: Aadhaar (context-triggered): 2345 6789 0125
+		NewRegexScanner(
+			regexp.MustCompile(`(?:(?i:\bAadhaar|\bAadhar|\bUIDAI|\bUID\b)|आधार(?:\s+(?:संख्या|नंबर|क्रमांक))?)(?i:\s+(?:No\.?|Number|Card))?`+apacSep+`([2-9]\d{3}[ \-]?\d{4}[ \-]?\d{4})\b`),
+			"SSN", 0.95,
+			WithExtractGroup(1),
+			WithValidator(validateAadhaar),
+			WithSubtype("aadhaar"),
+		),
+		// IN: Aadhaar standalone, grouped in fours
+		NewRegexScanner(
+			regexp.MustCompile(`\b[2-9]\d{3} \d{4} \d{4}\b`),
+			"SSN", 0.85,
+			WithValidator(validateAadhaar),
+			WithSubtype("aadhaar"),
+		),
+		// IN: PAN (context-triggered): ABCPE1234F, the fourth letter is the
+		// holder type
+		NewRegexScanner(
+			regexp.MustCompile(`(?:(?i:\bPAN\b|\bPermanent\s+Account\s+Number)|आयकर|स्थायी\s+खाता\s+संख्या)(?i:\s+(?:No\.?|Number|Card))?`+apacSep+`([A-Z]{3}[ABCFGHJLPT][A-Z]\d{4}[A-Z])\b`),
+			"ID_NUMBER", 0.95,
+			WithExtractGroup(1),
+			WithSubtype("pan"),
+		),
+		// CN: resident identity card (context-triggered): 11010519491231002X
+		NewRegexScanner(
+			regexp.MustCompile(`(?:(?:居民|公民)?身份(?:证|證)(?:号码|號碼|号|號)?|公民身份号码|(?i:\bResident\s+Identity\s+Card|\bChinese\s+ID))(?i:\s+(?:No\.?|Number))?`+apacSep+`(\d{17}[\dXx])\b`),
+			"SSN", 0.95,
+			WithExtractGroup(1),
+			WithValidator(validateChineseResidentID),
+			WithSubtype("cn_resident_id"),
+		),
+		// CN: resident identity card standalone
+		NewRegexScanner(
+			regexp.MustCompile(`\b[1-9]\d{16}[\dX]\b`),
+			"SSN", 0.90,
+			WithValidator(validateChineseResidentID),
+			WithSubtype("cn_resident_id"),
+		),
+		// JP: My Number (context-triggered): 1234 5678 9018
+		NewRegexScanner(
+			regexp.MustCompile(`(?:マイナンバー|個人番号|(?i:\bMy\s*Number|\bIndividual\s+Number))(?:は)?`+apacSep+`(\d{4}[ \-]?\d{4}[ \-]?\d{4})\b`),
+			"SSN", 0.95,
+			WithExtractGroup(1),
+			WithValidator(validateMyNumber),
+			WithSubtype("my_number"),
+		),
+		// KR: resident registration number (context-triggered): 900101-1234568.
+		// Numbers issued since October 2020 have no check digit, so only the
+		// date and the sex digit are validated here.
+		NewRegexScanner(
+			regexp.MustCompile(`(?:주민(?:등록)?번호|외국인등록번호|(?i:\bRRN\b|\bResident\s+Registration\s+Number))`+apacSep+`(\d{6}-?\d{7})\b`),
+			"SSN", 0.95,
+			WithExtractGroup(1),
+			WithValidator(validateRRNDate),
+			WithSubtype("rrn"),
+		),
+		// KR: resident registration number standalone, with check digit
+		NewRegexScanner(
+			regexp.MustCompile(`\b\d{6}-\d{7}\b`),
+			"SSN", 0.90,
+			WithValidator(validateRRN),
+			WithSubtype("rrn"),
+		),
+		// SG: NRIC (citizens and permanent residents): S1234567D
+		NewRegexScanner(
+			regexp.MustCompile(`\b[ST]\d{7}[A-Z]\b`),
+			"SSN", 0.90,
+			WithValidator(validateNRIC),
+			WithSubtype("nric"),
+		),
+		// SG: FIN (foreigners): G1234567X
+		NewRegexScanner(
+			regexp.MustCompile(`\b[FGM]\d{7}[A-Z]\b`),
+			"SSN", 0.90,
+			WithValidator(validateNRIC),
+			WithSubtype("fin"),
+		),
+		// AU: Tax File Number (context-triggered): 123 456 782
+		NewRegexScanner(
+			regexp.MustCompile(`(?:\bTFN\b|(?i:\bTax\s+File\s+Number))`+apacSep+`(\d{3}[ \-]?\d{3}[ \-]?\d{2,3})\b`),
+			"ID_NUMBER", 0.95,
+			WithExtractGroup(1),
+			WithValidator(validateTFN),
+			WithSubtype("tfn"),
+		),
+		// AU: Medicare card number (context-triggered): 2123 45670 1
+		NewRegexScanner(
+			regexp.MustCompile(`(?i:\bMedicare)(?i:\s+(?:Card\s+)?(?:No\.?|Number))?`+apacSep+`([2-6]\d{3}[ \-]?\d{5}[ \-]?\d(?:[ \-/]?\d)?)\b`),
+			"MEDICAL", 0.95,
+			WithExtractGroup(1),
+			WithValidator(validateAUMedicare),
+			WithSubtype("medicare"),
+		),
+		// AE: Emirates ID: 784-1985-1234567-3
+		NewRegexScanner(
+			regexp.MustCompile(`\b784-?\d{4}-?\d{7}-?\d\b`),
+			"SSN", 0.90,
+			WithValidator(validateLuhn),
+			WithSubtype("emirates_id"),
+		),
+		// SA: national ID (citizens, starting with 1) (context-triggered)
+		NewRegexScanner(
+			regexp.MustCompile(`(?:رقم الهوية(?: الوطنية)?|الهوية الوطنية|السجل المدني|(?i:\bSaudi\s+(?:National\s+)?ID|\bNational\s+ID))(?i:\s+(?:No\.?|Number))?`+apacSep+`(1\d{9})\b`),
+			"SSN", 0.95,
+			WithExtractGroup(1),
+			WithValidator(validateLuhn),
+			WithSubtype("sa_national_id"),
+		),
+		// SA: Iqama (residents, starting with 2) (context-triggered)
+		NewRegexScanner(
+			regexp.MustCompile(`(?:(?:رقم )?الإقامة|إقامة|(?i:\bIqama))(?i:\s+(?:No\.?|Number|ID))?`+apacSep+`(2\d{9})\b`),
+			"SSN", 0.95,
+			WithExtractGroup(1),
+			WithValidator(validateLuhn),
+			WithSubtype("iqama"),
+		),
+		// IL: Teudat Zehut (context-triggered): 123456782
+		NewRegexScanner(
+			regexp.MustCompile(`(?:תעודת זהות|מספר זהות|ת\.ז\.?|ת["״]ז|(?i:\bTeudat\s+Zehut|\bIsraeli\s+ID))(?i:\s+(?:No\.?|Number))?`+apacSep+`(\d{9})\b`),
+			"SSN", 0.95,
+			WithExtractGroup(1),
+			WithValidator(validateLuhn),
+			WithSubtype("teudat_zehut"),
+		),
+		// TR: TC Kimlik No (context-triggered): 10000000146
+		NewRegexScanner(
+			regexp.MustCompile(`(?i:\bT\.?\s?C\.?\s+Kimlik(?:\s+(?:No|Numarası|Numarasi))?|\bTCKN\b|\bKimlik\s+(?:No|Numarası|Numarasi))\.?`+apacSep+`([1-9]\d{10})\b`),
+			"SSN", 0.95,
+			WithExtractGroup(1),
+			WithValidator(validateTCKimlik),
+			WithSubtype("tc_kimlik"),
+		),
+	}
+}
+
+// validYMD reports whether year, month and day form a calendar date.
+func validYMD(year, month, day int) bool {
+	if month < 1 || month > 12 || day < 1 {
+		return false
+	}
+	return time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC).Day() == day
+}
+
+// verhoeffD and verhoeffP are the multiplication and permutation tables of
+// the Verhoeff check digit scheme.
+var (
+	verhoeffD = [10][10]int{
+		{0, 1, 2, 3, 4, 5, 6, 7, 8, 9},
+		{1, 2, 3, 4, 0, 6, 7, 8, 9, 5},
+		{2, 3, 4, 0, 1, 7, 8, 9, 5, 6},
+		{3, 4, 0, 1, 2, 8, 9, 5, 6, 7},
+		{4, 0, 1, 2, 3, 9, 5, 6, 7, 8},
+		{5, 9, 8, 7, 6, 0, 4, 3, 2, 1},
+		{6, 5, 9, 8, 7, 1, 0, 4, 3, 2},
+		{7, 6, 5, 9, 8, 2, 1, 0, 4, 3},
+		{8, 7, 6, 5, 9, 3, 2, 1, 0, 4},
+		{9, 8, 7, 6, 5, 4, 3, 2, 1, 0},
+	}
+	verhoeffP = [8][10]int{
+		{0, 1, 2, 3, 4, 5, 6, 7, 8, 9},
+		{1, 5, 7, 6, 2, 8, 3, 0, 9, 4},
+		{5, 8, 0, 3, 7, 9, 6, 1, 4, 2},
+		{8, 9, 1, 6, 0, 4, 3, 5, 2, 7},
+		{9, 4, 5, 3, 1, 2, 0, 7, 6, 8},
+		{4, 2, 8, 6, 5, 7, 3, 9, 0, 1},
+		{2, 7, 9, 3, 8, 0, 6, 4, 1, 5},
+		{7, 0, 4, 6, 9, 1, 3, 2, 5, 8},
+	}
+)
+
+// verhoeffValid reports whether digits end in a valid Verhoeff check digit.
+func verhoeffValid(digits []int) bool {
+	c := 0
+	for i := range digits {
+		c = verhoeffD[c][verhoeffP[i%8][digits[len(digits)-1-i]]]
+	}
+	return c == 0
+}
+
+// validateAadhaar checks the Verhoeff check digit of an Indian Aadhaar
+// number. Numbers starting with 0 or 1 are not issued, and 12 equal digits
+// are placeholders.
+func validateAadhaar(s string) bool {
+	d := digitValues(s)
+	return len(d) == 12 && d[0] >= 2 && !allSame(d) && verhoeffValid(d)
+}
+
+// validateChineseResidentID checks a Chinese resident identity number: the
+// birth date in digits 7–14 and the ISO 7064 MOD 11-2 check character
+// (0–9 or X for 10) over the first 17 digits.
+func validateChineseResidentID(s string) bool {
+	if len(s) != 18 {
+		return false
+	}
+	d := digitValues(s[:17])
+	if len(d) != 17 {
+		return false
+	}
+	year := d[6]*1000 + d[7]*100 + d[8]*10 + d[9]
+	if year < 1900 || !validYMD(year, d[10]*10+d[11], d[12]*10+d[13]) {
+		return false
+	}
+	sum := 0
+	for i, v := range d {
+		// The weight of position i is 2^(17-i) mod 11.
+		w := 1
+		for range 17 - i {
+			w = w * 2 % 11
+		}
+		sum += v * w
+	}
+	return strings.ToUpper(s[17:]) == string("10X98765432"[sum%11])
+}
+
+// validateMyNumber checks the check digit of a Japanese My Number: the
+// eleven digits before it, counted from the right, are weighted 2–7 and
+// then 2–6; a remainder modulo 11 of 0 or 1 gives 0, else 11 minus it.
+func validateMyNumber(s string) bool {
+	d := digitValues(s)
+	if len(d) != 12 {
+		return false
+	}
+	sum := 0
+	for n := 1; n <= 11; n++ {
+		w := n + 1
+		if n >= 7 {
+			w = n - 5
+		}
+		sum += d[11-n] * w
+	}
+	check := 0
+	if r := sum % 11; r > 1 {
+		check = 11 - r
+	}
+	return d[11] == check
+}
+
+// validateRRNDate checks the birth date and sex digit of a Korean resident
+// registration number (YYMMDD-SXXXXXX). The sex digit also gives the
+// century: 9/0 for 1800s, 1/2 and 5/6 (foreigners) for 1900s, 3/4 and 7/8
+// for 2000s.
+func validateRRNDate(s string) bool {
+	d := digitValues(s)
+	if len(d) != 13 {
+		return false
+	}
+	century := map[int]int{9: 1800, 0: 1800, 1: 1900, 2: 1900, 5: 1900, 6: 1900, 3: 2000, 4: 2000, 7: 2000, 8: 2000}[d[6]]
+	return validYMD(century+d[0]*10+d[1], d[2]*10+d[3], d[4]*10+d[5])
+}
+
+// validateRRN checks a Korean resident registration number including the
+// check digit issued before October 2020: the first 12 digits weighted
+// 2–9, 2–5, and (11 − sum mod 11) mod 10.
+func validateRRN(s string) bool {
+	if !validateRRNDate(s) {
+		return false
+	}
+	d := digitValues(s)
+	weights := []int{2, 3, 4, 5, 6, 7, 8, 9, 2, 3, 4, 5}
+	sum := 0
+	for i, w := range weights {
+		sum += d[i] * w
+	}
+	return d[12] == (11-sum%11)%10
+}
+
+// validateNRIC checks the check letter of a Singapore NRIC or FIN: the
+// seven digits are weighted 2, 7, 6, 5, 4, 3, 2, an offset is added for the
+// T/G (4) and M (3) series, and the remainder modulo 11 selects the letter
+// from the table of the series.
+func validateNRIC(s string) bool {
+	if len(s) != 9 {
+		return false
+	}
+	d := digitValues(s[1:8])
+	if len(d) != 7 {
+		return false
+	}
+	weights := []int{2, 7, 6, 5, 4, 3, 2}
+	sum := 0
+	for i, w := range weights {
+		sum += d[i] * w
+	}
+	var table string
+	switch s[0] {
+	case 'S':
+		table = "JZIHGFEDCBA"
+	case 'T':
+		table, sum = "JZIHGFEDCBA", sum+4
+	case 'F':
+		table = "XWUTRQPNMLK"
+	case 'G':
+		table, sum = "XWUTRQPNMLK", sum+4
+	case 'M':
+		table, sum = "KLJNPQRTUWX", sum+3
+	default:
+		return false
+	}
+	return s[8] == table[sum%11]
+}
+
+// validateTFN checks an Australian Tax File Number: the weighted sum of its
+// 9 (or legacy 8) digits must be divisible by 11.
+func validateTFN(s string) bool {
+	d := digitValues(s)
+	var weights []int
+	switch len(d) {
+	case 9:
+		weights = []int{1, 4, 3, 7, 5, 8, 6, 9, 10}
+	case 8:
+		weights = []int{10, 7, 8, 4, 6, 3, 5, 1}
+	default:
+		return false
+	}
+	sum := 0
+	for i, w := range weights {
+		sum += d[i] * w
+	}
+	return sum%11 == 0
+}
+
+// validateAUMedicare checks the check digit (ninth digit) of an Australian
+// Medicare card number: the first eight digits weighted 1, 3, 7, 9, 1, 3,
+// 7, 9, modulo 10. The tenth digit is the issue number, an optional
+// eleventh the individual reference number.
+func validateAUMedicare(s string) bool {
+	d := digitValues(s)
+	if len(d) != 10 && len(d) != 11 {
+		return false
+	}
+	weights := []int{1, 3, 7, 9, 1, 3, 7, 9}
+	sum := 0
+	for i, w := range weights {
+		sum += d[i] * w
+	}
+	return d[8] == sum%10
+}
+
+// validateTCKimlik checks both check digits of a Turkish TC Kimlik number:
+// the tenth is seven times the sum of the odd digits minus the sum of the
+// even digits (1–9), modulo 10; the eleventh the sum of the first ten,
+// modulo 10.
+func validateTCKimlik(s string) bool {
+	d := digitValues(s)
+	if len(d) != 11 || d[0] == 0 {
+		return false
+	}
+	odd := d[0] + d[2] + d[4] + d[6] + d[8]
+	even := d[1] + d[3] + d[5] + d[7]
+	if ((odd*7-even)%10+10)%10 != d[9] {
+		return false
+	}
+	sum := 0
+	for _, v := range d[:10] {
+		sum += v
+	}
+	return sum%10 == d[10]
+}
@@ -0,0 +1,92 @@
+package scanner
+
+import "testing"
+
+func TestAPAC_Detected(t *testing.T) {
+	s := DefaultScanner(nil)
+	cases := []struct {
+		name    string
+		input   string
+		typ     string
+		want    string
+		subtype string
+	}{
+		{"Aadhaar", "Aadhaar No: 2345 6789 0125", "SSN", "2345 6789 0125", "aadhaar"},
+		{"Aadhaar Hindi trigger", "आधार संख्या 234567890125", "SSN", "234567890125", "aadhaar"},
+		{"Aadhaar standalone", "holder 2345 6789 0125 verified", "SSN", "2345 6789 0125", "aadhaar"},
+		{"PAN", "PAN: ABCPE1234F", "ID_NUMBER", "ABCPE1234F", "pan"},
+		{"PAN long trigger", "Permanent Account Number ABCPE1234F", "ID_NUMBER", "ABCPE1234F", "pan"},
+		{"PAN Hindi trigger", "स्थायी खाता संख्या: ABCPE1234F", "ID_NUMBER", "ABCPE1234F", "pan"},
+		{"China ID Chinese trigger", "身份证号码：11010519491231002X", "SSN", "11010519491231002X", "cn_resident_id"},
+		{"China ID no separator", "公民身份号码11010519491231002X。", "SSN", "11010519491231002X", "cn_resident_id"},
+		{"China ID standalone", "ID 11010519491231002X on file", "SSN", "11010519491231002X", "cn_resident_id"},
+		{"My Number Japanese", "マイナンバー：1234 5678 9018", "SSN", "1234 5678 9018", "my_number"},
+		{"My Number particle", "個人番号は123456789018です", "SSN", "123456789018", "my_number"},
+		{"RRN Korean", "주민등록번호: 900101-1234568", "SSN", "900101-1234568", "rrn"},
+		{"RRN post-2020 without checksum", "주민번호 900101-1234567", "SSN", "900101-1234567", "rrn"},
+		{"RRN standalone", "holder 900101-1234568 registered", "SSN", "900101-1234568", "rrn"},
+		{"NRIC", "NRIC S1234567D", "SSN", "S1234567D", "nric"},
+		{"FIN", "FIN G1234567X", "SSN", "G1234567X", "fin"},
+		{"FIN M series", "FIN M1234567X", "SSN", "M1234567X", "fin"},
+		{"TFN", "TFN: 123 456 782", "ID_NUMBER", "123 456 782", "tfn"},
+		{"Medicare AU", "Medicare card number 2123 45670 1", "MEDICAL", "2123 45670 1", "medicare"},
+		{"Emirates ID", "Holder 784-1985-1234567-3", "SSN", "784-1985-1234567-3", "emirates_id"},
+		{"Saudi national ID Arabic", "رقم الهوية: 1012345672", "SSN", "1012345672", "sa_national_id"},
+		{"Iqama Arabic", "رقم الإقامة 2012345670", "SSN", "2012345670", "iqama"},
+		{"Iqama English", "Iqama No. 2012345670", "SSN", "2012345670", "iqama"},
+		{"Teudat Zehut Hebrew", "ת.ז. 123456782", "SSN", "123456782", "teudat_zehut"},
+		{"Teudat Zehut gershayim", "ת\"ז: 123456782", "SSN", "123456782", "teudat_zehut"},
+		{"TC Kimlik", "T.C. Kimlik No: 10000000146", "SSN", "10000000146", "tc_kimlik"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			for _, e := range s.Scan(tc.input) {
+				if e.Type == tc.typ && e.Text == tc.want {
+					if e.Subtype != tc.subtype {
+						t.Errorf("Subtype = %q, want %q", e.Subtype, tc.subtype)
+					}
+					return
+				}
+			}
+			t.Fatalf("%s %q not found in %q, got %v", tc.typ, tc.want, tc.input, s.Scan(tc.input))
+		})
+	}
+}
+
+func TestAPAC_ChecksumsRejected(t *testing.T) {
+	s := DefaultScanner(nil)
+	cases := []struct {
+		input string
+		typ   string
+	}{
+		{"Aadhaar No: 2345 6789 0124", "SSN"},
+		{"身份证号码：110105194912310021", "SSN"},
+		{"身份证号码：110105194913310026", "SSN"},
+		{"マイナンバー：1234 5678 9012", "SSN"},
+		{"주민등록번호: 901301-1234568", "SSN"},
+		{"holder 900101-1234567 registered", "SSN"},
+		{"NRIC S1234567A", "SSN"},
+		{"TFN: 123 456 789", "ID_NUMBER"},
+		{"Medicare card number 2123 45671 1", "MEDICAL"},
+		{"Holder 784-1985-1234567-1", "SSN"},
+		{"Iqama No. 2012345671", "SSN"},
+		{"ת.ז. 123456789", "SSN"},
+		{"T.C. Kimlik No: 10000000147", "SSN"},
+	}
+	for _, tc := range cases {
+		for _, e := range s.Scan(tc.input) {
+			if e.Type == tc.typ {
+				t.Errorf("invalid number detected in %q: %v", tc.input, e)
+			}
+		}
+	}
+}
+
+func TestAPAC_PANNeedsTrigger(t *testing.T) {
+	s := DefaultScanner(nil)
+	for _, input := range []string{"Order ABCPE1234F arrived.", "SKU XYZAB9876C in stock"} {
+		if n := countEntitiesOfType(s.Scan(input), "ID_NUMBER"); n != 0 {
+			t.Errorf("code without a PAN trigger flagged in %q", input)
+		}
+	}
+}
@@ -0,0 +1,153 @@
+package scanner
+
+import (
+	"regexp"
+	"strings"
+)
+
+// --- BANK_ACCOUNT ---
+
+// bankLinkGap is the largest number of bytes between two bank details that
+// still belong to the same account ("Routing 021000021, Account # 12345678").
+const bankLinkGap = 60
+
+// bankAccountScanners detects domestic bank account details: account
+// numbers, and the routing codes that identify the bank and branch (US ABA
+// routing numbers, UK sort codes, Australian BSBs, Canadian transit and
+// institution numbers, Indian IFSCs). The Subtype names the detail. None
+// of them is distinctive on its own and only routing numbers have a check,
+// so all need a trigger. IBANs keep their own type.
+func bankAccountScanners() []Scanner {
+	return []Scanner{
+		// US: ABA routing number (context-triggered): 021000021
+		NewRegexScanner(
+			regexp.MustCompile(`(?i:\bABA(?:\s+(?:Routing\s+)?(?:No\.?|Number|#))?|\bRouting(?:\s+(?:Transit\s+)?(?:No\.?|Number|#))?|\bRTN\b|\bRT#)[:\s#]*(\d{9})\b`),
+			"BANK_ACCOUNT", 0.95,
+			WithExtractGroup(1),
+			WithValidator(validateABA),
+			WithSubtype("aba_routing"),
+		),
+		// Account number (context-triggered): "Account # 12345678", "Acct 0012345678"
+		NewRegexScanner(
+			regexp.MustCompile(`(?i:\b(?:Bank\s+|Checking\s+|Savings\s+|Deposit\s+)?Account\s*(?:No\.?|Number|#)|\b(?:Checking|Savings)\s+Account|\bAcct\.?(?:\s*(?:No\.?|Number|#))?|\bA/C(?:\s*No\.?)?)[:\s#.]*(\d{4,17})\b`),
+			"BANK_ACCOUNT", 0.95,
+			WithExtractGroup(1),
+			WithSubtype("account_number"),
+		),
+		// UK: sort code (context-triggered): 20-00-00, 20 00 00, 200000
+		NewRegexScanner(
+			regexp.MustCompile(`(?i:\bsort[ \t-]?code)[:\s]*(\d{2}-\d{2}-\d{2}|\d{2} \d{2} \d{2}|\d{6})\b`),
+			"BANK_ACCOUNT", 0.90,
+			WithExtractGroup(1),
+			WithSubtype("sort_code"),
+		),
+		// AU: BSB (context-triggered): 062-000
+		NewRegexScanner(
+			regexp.MustCompile(`(?i:\bBSB(?:\s+(?:No\.?|Number|#|Code))?)[:\s#]*(\d{3}-?\d{3})\b`),
+			"BANK_ACCOUNT", 0.90,
+			WithExtractGroup(1),
+			WithSubtype("bsb"),
+		),
+		// CA: transit number (context-triggered), optionally with the
+		// institution in EFT form: 12345 or 12345-003
+		NewRegexScanner(
+			regexp.MustCompile(`(?i:\b(?:Branch\s+)?Transit(?:\s+(?:No\.?|Number|#))?)[:\s#]*(\d{5}(?:-\d{3})?)\b`),
+			"BANK_ACCOUNT", 0.90,
+			WithExtractGroup(1),
+			WithSubtype("transit"),
+		),
+		// CA: financial institution number (context-triggered): 003
+		NewRegexScanner(
+			regexp.MustCompile(`(?i:\bInstitution(?:\s+(?:No\.?|Number|#|Code))?)[:\s#]*(\d{3})\b`),
+			"BANK_ACCOUNT", 0.85,
+			WithExtractGroup(1),
+			WithSubtype("institution"),
+		),
+		// IN: IFSC (context-triggered): four-letter bank code, a 0,
+		// six-character branch code: SBIN0001234
+		NewRegexScanner(
+			regexp.MustCompile(`(?i:\bIFSC?(?:\s+Code)?)[:\s#-]*([A-Z]{4}0[A-Z0-9]{6})\b`),
+			"BANK_ACCOUNT", 0.90,
+			WithExtractGroup(1),
+			WithSubtype("ifsc"),
+		),
+	}
+}
+
+// validateABA checks a US ABA routing number: the first two digits are a
+// Federal Reserve routing symbol (00–12, 21–32, 61–72, 80) and the digits
+// weighted 3, 7, 1 repeating sum to a multiple of 10.
+func validateABA(s string) bool {
+	d := digitValues(s)
+	if len(d) != 9 {
+		return false
+	}
+	prefix := d[0]*10 + d[1]
+	if !(prefix <= 12 || prefix >= 21 && prefix <= 32 || prefix >= 61 && prefix <= 72 || prefix == 80) {
+		return false
+	}
+	sum := 0
+	for i := 0; i < 9; i += 3 {
+		sum += 3*d[i] + 7*d[i+1] + d[i+2]
+	}
+	return sum%10 == 0
+}
+
+// linkBankAccounts gives the bank details that describe one account the
+// same Link ID: an account number and the routing codes next to it, in
+// either order, with at most bankLinkGap bytes and no blank line between
+// neighbours. A group holds at most one detail per subtype and ends at a
+// line break once it has an account number, so consecutive accounts stay
+// apart. If one member belongs to a person's cluster, the
+// others do too. entities must be sorted by Start.
+func linkBankAccounts(text string, entities []Entity) []Entity {
+	var group []int
+	link := 0
+	flush := func() {
+		hasAccount := false
+		for _, i := range group {
+			hasAccount = hasAccount || entities[i].Subtype == "account_number"
+		}
+		if len(group) >= 2 && hasAccount {
+			link++
+			cluster := 0
+			for _, i := range group {
+				entities[i].Link = link
+				if cluster == 0 {
+					cluster = entities[i].Cluster
+				}
+			}
+			for _, i := range group {
+				entities[i].Cluster = cluster
+			}
+		}
+		group = group[:0]
+	}
+	for i, e := range entities {
+		if e.Type != "BANK_ACCOUNT" {
+			continue
+		}
+		if len(group) > 0 {
+			prev := entities[group[len(group)-1]]
+			between := text[prev.End:e.Start]
+			if e.Start-prev.End > bankLinkGap || blockSepRe.MatchString(between) || hasSubtype(entities, group, e.Subtype) ||
+				strings.Contains(between, "\n") && hasSubtype(entities, group, "account_number") {
+				flush()
+			}
+		}
+		group = append(group, i)
+	}
+	flush()
+	return entities
+}
+
+// hasSubtype reports whether one of the entities at the given indexes has
+// the subtype.
+func hasSubtype(entities []Entity, indexes []int, subtype string) bool {
+	for _, i := range indexes {
+		if entities[i].Subtype == subtype {
+			return true
+		}
+	}
+	return false
+}
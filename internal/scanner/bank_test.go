@@ -0,0 +1,112 @@
+package scanner
+
+import "testing"
+
+func TestBankAccount_Detected(t *testing.T) {
+	s := DefaultScanner(nil)
+	cases := []struct {
+		name    string
+		input   string
+		want    string
+		subtype string
+	}{
+		{"ABA routing", "ABA Routing Number: 021000021", "021000021", "aba_routing"},
+		{"account #", "Account # 000123456789", "000123456789", "account_number"},
+		{"acct", "Acct. 4401234567", "4401234567", "account_number"},
+		{"sort code", "Sort code: 40-47-84", "40-47-84", "sort_code"},
+		{"BSB", "BSB 062-000", "062-000", "bsb"},
+		{"CA transit", "Transit No. 12345-003", "12345-003", "transit"},
+		{"CA institution", "Institution number 003", "003", "institution"},
+		{"IFSC", "IFSC: SBIN0001234", "SBIN0001234", "ifsc"},
+		{"IFS code", "IFS Code HDFC0000123", "HDFC0000123", "ifsc"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			for _, e := range s.Scan(tc.input) {
+				if e.Type == "BANK_ACCOUNT" && e.Text == tc.want {
+					if e.Subtype != tc.subtype {
+						t.Errorf("Subtype = %q, want %q", e.Subtype, tc.subtype)
+					}
+					return
+				}
+			}
+			t.Fatalf("BANK_ACCOUNT %q not found in %q, got %v", tc.want, tc.input, s.Scan(tc.input))
+		})
+	}
+}
+
+func TestBankAccount_InvalidRoutingRejected(t *testing.T) {
+	s := DefaultScanner(nil)
+	for _, input := range []string{"ABA Routing Number: 021000022", "Routing 991000021"} {
+		for _, e := range s.Scan(input) {
+			if e.Type == "BANK_ACCOUNT" {
+				t.Errorf("invalid routing number detected in %q: %v", input, e)
+			}
+		}
+	}
+}
+
+func TestBankAccount_UKSortCodeAndAccount(t *testing.T) {
+	s := DefaultScanner(nil)
+	cases := []struct {
+		name     string
+		input    string
+		sortCode string
+		account  string
+	}{
+		{"sort code and account", "Sort code 20-00-00, Account No. 55779911", "20-00-00", "55779911"},
+		{"account before sort code", "Acct 55779911 / Sort Code 200000", "200000", "55779911"},
+		{"spaced sort code", "Sort code 20 00 00, Account No. 55779911", "20 00 00", "55779911"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			found := make(map[string]Entity)
+			for _, e := range s.Scan(tc.input) {
+				if e.Type == "BANK_ACCOUNT" {
+					found[e.Subtype] = e
+				}
+			}
+			sortCode, account := found["sort_code"], found["account_number"]
+			if sortCode.Text != tc.sortCode || account.Text != tc.account {
+				t.Fatalf("sort code %q, account %q, want %q and %q in %q", sortCode.Text, account.Text, tc.sortCode, tc.account, tc.input)
+			}
+			if sortCode.Link == 0 || sortCode.Link != account.Link {
+				t.Errorf("sort code and account not linked: %d, %d", sortCode.Link, account.Link)
+			}
+		})
+	}
+}
+
+func TestBankAccount_IFSCNeedsTrigger(t *testing.T) {
+	s := DefaultScanner(nil)
+	for _, input := range []string{"TEST0123456", "Build ABCD0XYZ123 failed"} {
+		for _, e := range s.Scan(input) {
+			if e.Type == "BANK_ACCOUNT" {
+				t.Errorf("IFSC without trigger detected in %q: %v", input, e)
+			}
+		}
+	}
+}
+
+func TestBankAccount_Linked(t *testing.T) {
+	s := DefaultScanner(nil)
+	text := "Routing 021000021, Account # 12345678\nSort code 20-00-00, Account No. 55779911\n\nAccount No. 99887766"
+	links := make(map[string]int)
+	for _, e := range s.Scan(text) {
+		if e.Type == "BANK_ACCOUNT" {
+			links[e.Text] = e.Link
+		}
+	}
+	if links["021000021"] == 0 || links["021000021"] != links["12345678"] {
+		t.Errorf("routing and account not linked: %v", links)
+	}
+	if links["20-00-00"] == 0 || links["20-00-00"] != links["55779911"] {
+		t.Errorf("sort code and account not linked: %v", links)
+	}
+	if links["12345678"] == links["55779911"] {
+		t.Errorf("separate accounts share a link: %v", links)
+	}
+	if links["99887766"] != 0 {
+		t.Errorf("lone account number linked: %v", links)
+	}
+}
@@ -0,0 +1,324 @@
+package scanner
+
+import (
+	"math"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// --- Canonicalization ---
+
+// canonicalText is a de-obfuscated copy of the scanned text together with
+// the offset map back to the original.
+type canonicalText struct {
+	text string
+	// start[i] and end[i] delimit the original bytes that produced byte i
+	// of text. A collapsed or replaced run maps every output byte to the
+	// whole original run.
+	start, end []int
+}
+
+// invisibleRunes are stripped before scanning: zero-width characters, soft
+// hyphens, word joiners and bidi controls that split tokens invisibly.
+var invisibleRunes = map[rune]bool{
+	'\u00AD': true, '\u180E': true, '\u200B': true, '\u200C': true, '\u200D': true,
+	'\u2060': true, '\u2061': true, '\u2062': true, '\u2063': true, '\u2064': true,
+	'\u202A': true, '\u202B': true, '\u202C': true, '\u202D': true, '\u202E': true,
+	'\u2066': true, '\u2067': true, '\u2068': true, '\u2069': true, '\uFEFF': true,
+}
+
+// confusables maps Cyrillic and Greek letters that look like Latin ones.
+// They are folded only inside tokens that also contain Latin letters or
+// digits, so genuine Cyrillic and Greek text is left alone.
+var confusables = map[rune]rune{
+	// Cyrillic lowercase
+	'а': 'a', 'с': 'c', 'ԁ': 'd', 'е': 'e', 'һ': 'h', 'і': 'i', 'ј': 'j', 'ӏ': 'l',
+	'о': 'o', 'р': 'p', 'ԛ': 'q', 'ѕ': 's', 'ԝ': 'w', 'х': 'x', 'у': 'y',
+	// Cyrillic uppercase
+	'А': 'A', 'В': 'B', 'С': 'C', 'Е': 'E', 'Н': 'H', 'І': 'I', 'Ј': 'J', 'К': 'K',
+	'М': 'M', 'О': 'O', 'Р': 'P', 'Ѕ': 'S', 'Т': 'T', 'Х': 'X', 'У': 'Y',
+	// Greek
+	'α': 'a', 'ο': 'o', 'ν': 'v', 'ρ': 'p', 'Α': 'A', 'Β': 'B', 'Ε': 'E', 'Ζ': 'Z',
+	'Η': 'H', 'Ι': 'I', 'Κ': 'K', 'Μ': 'M', 'Ν': 'N', 'Ο': 'O', 'Ρ': 'P', 'Τ': 'T',
+	'Χ': 'X', 'Υ': 'Y',
+}
+
+var (
+	// spacedOutRe matches five or more single characters separated by single
+	// spaces: "j o h n @ e x a m p l e . c o m", "4 1 1 1 1 1 1 1".
+	spacedOutRe = regexp.MustCompile(`\b[A-Za-z0-9] (?:[A-Za-z0-9@.+_-] ){3,}[A-Za-z0-9]\b`)
+	// bracketAtRe and bracketDotRe match "[at]", "(at)", "{dot}", "<punkt>".
+	// Lowercase only: "Wien (AT)" is a country code, not an obfuscated "@".
+	bracketAtRe  = regexp.MustCompile(`[ \t]*[\[({<][ \t]*(?:at|ät|@)[ \t]*[\])}>][ \t]*`)
+	bracketDotRe = regexp.MustCompile(`[ \t]*[\[({<][ \t]*(?:dot|punkt|point|\.)[ \t]*[\])}>][ \t]*`)
+	// wordAtDotRe matches "john at example dot com": plain "at" and "dot"
+	// words, ending in a TLD-like label.
+	wordAtDotRe = regexp.MustCompile(`[A-Za-z0-9._%+-]+[ \t]+(?:at|AT)[ \t]+[A-Za-z0-9-]+(?:[ \t]+(?:dot|DOT)[ \t]+[A-Za-z0-9-]+)*[ \t]+(?:dot|DOT)[ \t]+[A-Za-z]{2,6}\b`)
+	wordAtRe    = regexp.MustCompile(`[ \t]+(?:at|AT)[ \t]+`)
+	wordDotRe   = regexp.MustCompile(`[ \t]+(?:dot|DOT)[ \t]+`)
+)
+
+// spelledAtStopwords are words that stand around a plain "at" in prose
+// ("call me at home", "arrived at noon"), not a local part or domain.
+var spelledAtStopwords = map[string]bool{
+	"me": true, "us": true, "you": true, "him": true, "her": true, "them": true, "it": true,
+	"we": true, "i": true, "be": true, "is": true, "are": true, "was": true, "were": true,
+	"am": true, "here": true, "there": true, "back": true, "up": true, "out": true, "off": true,
+	"look": true, "looked": true, "arrive": true, "arrived": true, "stay": true, "stayed": true,
+	"meet": true, "met": true, "call": true, "home": true, "work": true, "school": true,
+	"night": true, "noon": true, "least": true, "most": true, "all": true, "once": true,
+	"first": true, "last": true, "the": true, "a": true, "an": true, "this": true, "that": true,
+	"my": true, "your": true, "our": true, "his": true, "their": true, "its": true, "one": true,
+	"some": true, "any": true, "times": true, "best": true, "risk": true, "hand": true, "rest": true,
+}
+
+// spelledTLDs are the top-level domains accepted in addresses spelled out
+// with plain words ("john at example dot com").
+var spelledTLDs = map[string]bool{
+	"com": true, "org": true, "net": true, "edu": true, "gov": true, "info": true, "biz": true,
+	"io": true, "co": true, "eu": true, "de": true, "at": true, "ch": true, "uk": true, "fr": true,
+	"it": true, "es": true, "nl": true, "be": true, "lu": true, "pl": true, "cz": true, "sk": true,
+	"hu": true, "se": true, "no": true, "dk": true, "fi": true, "ie": true, "pt": true, "gr": true,
+	"us": true, "ca": true, "au": true, "in": true,
+}
+
+// deobfuscatedPenalty scales the score of entities found only after
+// undoing obfuscation, keeping them below direct matches.
+const deobfuscatedPenalty = 0.9
+
+// canonicalize undoes common obfuscation so the scanners see what a human
+// reader sees: invisible characters are stripped, full-width and other
+// compatibility forms are folded, Cyrillic and Greek look-alikes inside
+// Latin tokens become Latin, spaced-out characters are collapsed and
+// "[at]"/"dot" spellings become "@" and ".". Exotic spaces are already
+// gone (see Normalize).
+//
+// In transcript mode, spoken numbers and dates are converted to digits
+// as well (see spokenNumbers).
+//
+// It returns nil when text contains none of these, which is the common case.
+func canonicalize(text string, transcript bool) *canonicalText {
+	if !transcript && !needsCanonicalization(text) {
+		return nil
+	}
+
+	c := foldRunes(text)
+	if transcript {
+		c = c.spokenNumbers()
+	}
+	c = c.rewrite(spacedOutRe, func(m string) string {
+		return strings.ReplaceAll(m, " ", "")
+	}, wholeToken)
+	c = c.rewrite(bracketAtRe, func(string) string { return "@" }, betweenWords)
+	c = c.rewrite(bracketDotRe, func(string) string { return "." }, betweenWords)
+	c = c.rewrite(wordAtDotRe, func(m string) string {
+		return wordDotRe.ReplaceAllString(wordAtRe.ReplaceAllString(m, "@"), ".")
+	}, spelledEmail)
+	if c.text == text {
+		return nil
+	}
+	return c
+}
+
+// needsCanonicalization is a cheap pre-check so that clean text skips the
+// offset bookkeeping entirely.
+func needsCanonicalization(text string) bool {
+	for _, r := range text {
+		if r >= 0x80 && (invisibleRunes[r] || foldsToASCII(r) || confusables[r] != 0) {
+			return true
+		}
+	}
+	return spacedOutRe.MatchString(text) || bracketAtRe.MatchString(text) ||
+		bracketDotRe.MatchString(text) || wordAtDotRe.MatchString(text)
+}
+
+// foldsToASCII reports whether r is a full-width form or a mathematical
+// alphanumeric, both of which fold to ASCII.
+func foldsToASCII(r rune) bool {
+	return (r >= 0xFF01 && r <= 0xFF5E) || (r >= 0x1D400 && r <= 0x1D7FF)
+}
+
+// isExoticSpace reports whether r is a no-break, ideographic or typographic space.
+func isExoticSpace(r rune) bool {
+	return r == '\u00A0' || r == '\u202F' || r == '\u3000' || (r >= 0x2000 && r <= 0x200A)
+}
+
+// Normalize returns text in the form entity offsets refer to: NFC, with
+// no-break, ideographic and typographic spaces replaced by ASCII spaces.
+// Those spaces are ordinary typography ("+49 170 1234567" with no-break
+// spaces, French and German number grouping), not obfuscation.
+func Normalize(text string) string {
+	text = norm.NFC.String(text)
+	if strings.IndexFunc(text, isExoticSpace) < 0 {
+		return text
+	}
+	return strings.Map(func(r rune) rune {
+		if isExoticSpace(r) {
+			return ' '
+		}
+		return r
+	}, text)
+}
+
+// foldRunes applies the per-character folds.
+func foldRunes(text string) *canonicalText {
+	c := &canonicalText{}
+	var sb strings.Builder
+	sb.Grow(len(text))
+	tokenStart := 0
+	latinToken := false
+	for i := 0; i < len(text); {
+		r, size := utf8.DecodeRuneInString(text[i:])
+		if i >= tokenStart {
+			// Entering a new whitespace-delimited token: decide once whether
+			// look-alikes in it should be folded.
+			tokenEnd := strings.IndexFunc(text[i:], unicode.IsSpace)
+			if tokenEnd < 0 {
+				tokenEnd = len(text) - i
+			}
+			tokenStart = i + max(tokenEnd, size)
+			latinToken = hasLatinOrDigit(text[i:tokenStart])
+		}
+
+		out := string(r)
+		switch {
+		case invisibleRunes[r]:
+			out = ""
+		case foldsToASCII(r):
+			out = norm.NFKC.String(out)
+		case latinToken && confusables[r] != 0:
+			out = string(confusables[r])
+		}
+		c.write(&sb, out, i, i+size)
+		i += size
+	}
+	c.text = sb.String()
+	return c
+}
+
+func hasLatinOrDigit(token string) bool {
+	for _, r := range token {
+		if r < 0x80 && (unicode.IsLetter(r) || unicode.IsDigit(r)) || (r >= 0xFF10 && r <= 0xFF5A) {
+			return true
+		}
+	}
+	return false
+}
+
+// write appends out to sb, recording [from, to) as its original span.
+func (c *canonicalText) write(sb *strings.Builder, out string, from, to int) {
+	sb.WriteString(out)
+	for range len(out) {
+		c.start = append(c.start, from)
+		c.end = append(c.end, to)
+	}
+}
+
+// wholeToken accepts a match only if it is delimited by whitespace or the
+// text boundaries, so "a b c d e" inside a longer spaced token is not cut.
+func wholeToken(text string, start, end int) bool {
+	if start > 0 {
+		if r, _ := utf8.DecodeLastRuneInString(text[:start]); !unicode.IsSpace(r) {
+			return false
+		}
+	}
+	if end < len(text) {
+		if r, _ := utf8.DecodeRuneInString(text[end:]); !unicode.IsSpace(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// betweenWords accepts a match only if it joins two words, as the "[at]"
+// in "john [at] example" does.
+func betweenWords(text string, start, end int) bool {
+	if start == 0 || end == len(text) {
+		return false
+	}
+	before, _ := utf8.DecodeLastRuneInString(text[:start])
+	after, _ := utf8.DecodeRuneInString(text[end:])
+	return (unicode.IsLetter(before) || unicode.IsDigit(before)) &&
+		(unicode.IsLetter(after) || unicode.IsDigit(after))
+}
+
+// spelledEmail accepts a "john at example dot com" match only if the words
+// around "at" are no prose stopwords and it ends in a known TLD, so that
+// "call me at home dot com" stays prose.
+func spelledEmail(text string, start, end int) bool {
+	m := text[start:end]
+	at := wordAtRe.FindStringIndex(m)
+	labels := wordDotRe.Split(m[at[1]:], -1)
+	if spelledAtStopwords[strings.ToLower(m[:at[0]])] || spelledAtStopwords[strings.ToLower(labels[0])] {
+		return false
+	}
+	return spelledTLDs[strings.ToLower(labels[len(labels)-1])]
+}
+
+// rewrite replaces every match of re with replace(match), composing the
+// offset map. accept, if non-nil, filters matches by position.
+func (c *canonicalText) rewrite(re *regexp.Regexp, replace func(string) string, accept func(text string, start, end int) bool) *canonicalText {
+	var edits []textEdit
+	for _, m := range re.FindAllStringIndex(c.text, -1) {
+		if accept != nil && !accept(c.text, m[0], m[1]) {
+			continue
+		}
+		edits = append(edits, textEdit{start: m[0], end: m[1], out: replace(c.text[m[0]:m[1]])})
+	}
+	return c.apply(edits)
+}
+
+// textEdit replaces text[start:end] with out.
+type textEdit struct {
+	start, end int
+	out        string
+}
+
+// apply performs edits, which must be sorted and non-overlapping, and
+// composes the offset map. Every byte of a replacement maps to the whole
+// original span of the bytes it replaces.
+func (c *canonicalText) apply(edits []textEdit) *canonicalText {
+	if len(edits) == 0 {
+		return c
+	}
+	out := &canonicalText{}
+	var sb strings.Builder
+	sb.Grow(len(c.text))
+	prev := 0
+	for _, e := range edits {
+		for j := prev; j < e.start; j++ {
+			out.write(&sb, c.text[j:j+1], c.start[j], c.end[j])
+		}
+		if e.end > e.start {
+			out.write(&sb, e.out, c.start[e.start], c.end[e.end-1])
+		}
+		prev = e.end
+	}
+	for j := prev; j < len(c.text); j++ {
+		out.write(&sb, c.text[j:j+1], c.start[j], c.end[j])
+	}
+	out.text = sb.String()
+	return out
+}
+
+// restore maps entities found in the canonical text back to the original.
+// Entities whose original span differs from what the scanners saw are
+// flagged as Deobfuscated and scored lower; their Text is the original
+// span.
+func (c *canonicalText) restore(original string, entities []Entity) []Entity {
+	for i, e := range entities {
+		start, end := c.start[e.Start], c.end[e.End-1]
+		entities[i].Start, entities[i].End = start, end
+		entities[i].Text = original[start:end]
+		entities[i].Deobfuscated = entities[i].Text != e.Text
+		if entities[i].Deobfuscated {
+			entities[i].Score = math.Round(e.Score*deobfuscatedPenalty*100) / 100
+		}
+		entities[i].Parts = c.restore(original, e.Parts)
+	}
+	return entities
+}
@@ -0,0 +1,122 @@
+package scanner
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCanonicalize_Deobfuscation(t *testing.T) {
+	s := DefaultScanner(nil)
+	cases := []struct {
+		name  string
+		input string
+		typ   string
+		want  string
+	}{
+		{"spaced-out email", "Mail: j o h n @ e x a m p l e . c o m bitte", "EMAIL", "j o h n @ e x a m p l e . c o m"},
+		{"bracketed at and dot", "Kontakt: john [at] example [dot] com", "EMAIL", "john [at] example [dot] com"},
+		{"spelled-out at and dot", "write to john at example dot com today", "EMAIL", "john at example dot com"},
+		{"zero-width IBAN", "IBAN DE89\u200b3704\u200d0044\u200b0532\u200b0130\u200b00", "IBAN", "DE89\u200b3704\u200d0044\u200b0532\u200b0130\u200b00"},
+		{"Cyrillic look-alikes", "Mail an jоhn@exаmple.com", "EMAIL", "jоhn@exаmple.com"},
+		{"full-width phone", "Tel: ＋４９ ３０ １２３４５６７", "PHONE", "＋４９ ３０ １２３４５６７"},
+		{"spaced card digits", "Karte 4 1 1 1 1 1 1 1 1 1 1 1 1 1 1 1 gesperrt", "CREDIT_CARD", "4 1 1 1 1 1 1 1 1 1 1 1 1 1 1 1"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			entities := s.Scan(tc.input)
+			var found *Entity
+			for i, e := range entities {
+				if e.Type == tc.typ {
+					found = &entities[i]
+				}
+			}
+			if found == nil {
+				t.Fatalf("%s not detected in %q, got %v", tc.typ, tc.input, entities)
+			}
+			if found.Text != tc.want {
+				t.Errorf("Text = %q, want %q", found.Text, tc.want)
+			}
+			if tc.input[found.Start:found.End] != found.Text {
+				t.Errorf("offsets [%d:%d] do not point at %q", found.Start, found.End, found.Text)
+			}
+			if !found.Deobfuscated {
+				t.Errorf("Deobfuscated not set on %v", *found)
+			}
+			direct := s.Scan("thomas@example.com 4111111111111111 DE89370400440532013000")
+			for _, d := range direct {
+				if d.Type == found.Type && found.Score >= d.Score {
+					t.Errorf("Score = %g, want below the direct match's %g", found.Score, d.Score)
+				}
+			}
+		})
+	}
+}
+
+func TestCanonicalize_LeavesTextAlone(t *testing.T) {
+	cases := []struct {
+		name  string
+		input string
+	}{
+		{"plain ASCII", "Thomas Schmidt, thomas@example.com"},
+		{"Greek text", "Η Αθήνα είναι όμορφη."},
+		{"Cyrillic text", "Москва и Санкт-Петербург"},
+		{"country code in brackets", "Firma Wien (AT) GmbH"},
+		{"short spaced letters", "a b c d"},
+		{"at and dot in prose", "Call me at home dot com"},
+		{"at without a known TLD", "Peter at Acme dot matrix"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if c := canonicalize(tc.input, false); c != nil {
+				t.Errorf("canonicalize(%q) = %q, want no change", tc.input, c.text)
+			}
+		})
+	}
+}
+
+func TestCanonicalize_PlainMatchesNotFlagged(t *testing.T) {
+	s := DefaultScanner(nil)
+	input := "Mail an jоhn@exаmple.com oder anna@example.com"
+	for _, e := range s.Scan(input) {
+		if e.Text == "anna@example.com" && e.Deobfuscated {
+			t.Errorf("unobfuscated entity flagged: %v", e)
+		}
+	}
+}
+
+func TestScan_NoBreakSpacesNotDeobfuscated(t *testing.T) {
+	s := DefaultScanner(nil)
+	cases := []struct {
+		name, input, typ string
+	}{
+		{"phone", "Tel. +49\u00a0170\u00a01234567", "PHONE"},
+		{"IBAN", "IBAN DE89\u202f3704\u202f0044\u202f0532\u202f0130\u202f00", "IBAN"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			plain := Normalize(tc.input)
+			if strings.ContainsAny(plain, "\u00a0\u202f") {
+				t.Fatalf("Normalize(%q) = %q, want ASCII spaces", tc.input, plain)
+			}
+			var want *Entity
+			for _, e := range s.Scan(plain) {
+				if e.Type == tc.typ {
+					want = &e
+				}
+			}
+			if want == nil {
+				t.Fatalf("%s not detected in %q", tc.typ, plain)
+			}
+			for _, e := range s.Scan(tc.input) {
+				if e.Type != tc.typ {
+					continue
+				}
+				if e.Deobfuscated || e.Score != want.Score || e.Text != want.Text {
+					t.Errorf("got %v, want %v", e, *want)
+				}
+				return
+			}
+			t.Errorf("%s not detected in %q", tc.typ, tc.input)
+		})
+	}
+}
@@ -0,0 +1,275 @@
+package scanner
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// --- Identity clustering ---
+
+// Cluster groups the entities that belong to one individual: the mentions
+// of their name and the contact details, identifiers and addresses linked
+// to it.
+type Cluster struct {
+	ID       int      `json:"id"`
+	Name     string   `json:"name"` // full name of the individual
+	Entities []Entity `json:"entities"`
+}
+
+// unclusteredTypes are entity types that describe organisations, places or
+// amounts rather than an individual and are never linked to a person.
+var unclusteredTypes = map[string]bool{
+	"ORG":             true,
+	"LOCATION":        true,
+	"FINANCIAL":       true,
+	"SECRET":          true,
+	"LEGAL_REFERENCE": true,
+}
+
+// blockSepRe separates blocks of text: paragraphs, signatures and address
+// blocks are delimited by blank lines.
+var blockSepRe = regexp.MustCompile(`\n[ \t]*\r?\n`)
+
+// identity is one individual found in the text.
+type identity struct {
+	cluster int
+	// given and surname hold ASCII-folded spellings for email matching.
+	given, surname []string
+	// mentionStarts and mentionEnds are the offsets of the name mentions.
+	mentionStarts, mentionEnds []int
+	// blocks holds the indexes of the blocks the name is mentioned in.
+	blocks map[int]bool
+}
+
+// clusterIdentities assigns a Cluster ID to every PERSON mention and to the
+// entities linked to that person. Mentions of the same individual share an
+// ID: identical names, "Surname, Given" and "Given Surname" forms, and
+// partial mentions resolved by coreference. Other entities are linked when
+//
+//   - they are an email address whose local part matches the name,
+//   - they share a block (paragraph, signature, address block) with
+//     exactly one individual, or
+//   - failing that, a name is mentioned on the same line; the nearest wins.
+//
+// entities must be sorted by Start.
+func clusterIdentities(text string, entities []Entity) []Entity {
+	blockStarts := []int{0}
+	for _, loc := range blockSepRe.FindAllStringIndex(text, -1) {
+		blockStarts = append(blockStarts, loc[1])
+	}
+	blockOf := func(offset int) int {
+		return sort.SearchInts(blockStarts, offset+1) - 1
+	}
+
+	var ids []*identity
+	byKey := make(map[string]*identity)
+	for i, e := range entities {
+		if e.Type != "PERSON" {
+			continue
+		}
+		key := identityKey(e)
+		if key == "" {
+			continue // shared surname, not attributable
+		}
+		id, ok := byKey[key]
+		if !ok {
+			id = &identity{cluster: len(ids) + 1, blocks: make(map[int]bool)}
+			given, surname, _ := splitPersonName(personName(e))
+			id.given, id.surname = foldedSpellings(given), foldedSpellings(surname)
+			ids = append(ids, id)
+			byKey[key] = id
+		}
+		id.mentionStarts = append(id.mentionStarts, e.Start)
+		id.mentionEnds = append(id.mentionEnds, e.End)
+		id.blocks[blockOf(e.Start)] = true
+		entities[i].Cluster = id.cluster
+	}
+	if len(ids) == 0 {
+		return entities
+	}
+
+	for i, e := range entities {
+		if e.Type == "PERSON" || unclusteredTypes[e.Type] {
+			continue
+		}
+		if e.Type == "EMAIL" {
+			if id := matchEmailIdentity(e.Text, ids); id != nil {
+				entities[i].Cluster = id.cluster
+				continue
+			}
+		}
+		block := blockOf(e.Start)
+		var only *identity
+		count := 0
+		for _, id := range ids {
+			if id.blocks[block] {
+				only = id
+				count++
+			}
+		}
+		if count == 1 {
+			entities[i].Cluster = only.cluster
+			continue
+		}
+		if id := nearestOnLine(text, e, ids); id != nil {
+			entities[i].Cluster = id.cluster
+		}
+	}
+	return entities
+}
+
+// identityKey returns the key under which mentions of the same individual
+// are grouped, or "" for mentions that cannot be attributed.
+func identityKey(e Entity) string {
+	if e.Detector == "coreference" && e.Canonical == "" {
+		return ""
+	}
+	name := personName(e)
+	if given, surname, _ := splitPersonName(name); given != "" {
+		return strings.ToLower(given + " " + surname)
+	}
+	return strings.ToLower(name)
+}
+
+// personName returns the full name a PERSON entity refers to.
+func personName(e Entity) string {
+	if e.Canonical != "" {
+		return e.Canonical
+	}
+	return e.Text
+}
+
+// matchEmailIdentity returns the single individual whose name matches the
+// local part of an email address ("thomas.schmidt", "t.schmidt",
+// "schmidt", "thomas"), or nil if none or several match.
+func matchEmailIdentity(email string, ids []*identity) *identity {
+	local, _, ok := strings.Cut(email, "@")
+	if !ok {
+		return nil
+	}
+	forms := foldedSpellings(local)
+
+	var match *identity
+	for _, id := range ids {
+		if !emailMatchesName(forms, id) {
+			continue
+		}
+		if match != nil {
+			return nil
+		}
+		match = id
+	}
+	return match
+}
+
+// emailMatchesName reports whether a folded local part contains the
+// surname or consists of the given name alone.
+func emailMatchesName(forms []string, id *identity) bool {
+	for _, local := range forms {
+		for _, s := range id.surname {
+			if len(s) >= 3 && strings.Contains(local, s) {
+				return true
+			}
+		}
+		for _, g := range id.given {
+			if local == g {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// nearestOnLine returns the individual mentioned on the same line as e:
+// the closest mention before it ("Thomas Schmidt, Tel. …"), or else the
+// closest one after it.
+func nearestOnLine(text string, e Entity, ids []*identity) *identity {
+	lineStart := strings.LastIndexByte(text[:e.Start], '\n') + 1
+	lineEnd := len(text)
+	if i := strings.IndexByte(text[e.End:], '\n'); i >= 0 {
+		lineEnd = e.End + i
+	}
+
+	var before, after *identity
+	beforeEnd, afterStart := -1, lineEnd+1
+	for _, id := range ids {
+		for k, start := range id.mentionStarts {
+			end := id.mentionEnds[k]
+			switch {
+			case start < lineStart || end > lineEnd:
+			case end <= e.Start && end > beforeEnd:
+				before, beforeEnd = id, end
+			case start >= e.End && start < afterStart:
+				after, afterStart = id, start
+			}
+		}
+	}
+	if before != nil {
+		return before
+	}
+	return after
+}
+
+// foldedSpellings returns the lowercase ASCII spellings of a name as they
+// appear in email addresses: "Müller" → "mueller", "muller".
+func foldedSpellings(name string) []string {
+	if name == "" {
+		return nil
+	}
+	lower := strings.ToLower(name)
+	german := strings.NewReplacer("ä", "ae", "ö", "oe", "ü", "ue", "ß", "ss").Replace(lower)
+	spellings := []string{stripMarks(german)}
+	if plain := stripMarks(lower); plain != spellings[0] {
+		spellings = append(spellings, plain)
+	}
+	return spellings
+}
+
+// stripMarks removes diacritics and every non-letter: "jean-rené" → "jeanrene".
+func stripMarks(s string) string {
+	return strings.Map(func(r rune) rune {
+		if !unicode.IsLetter(r) {
+			return -1
+		}
+		return r
+	}, norm.NFD.String(s))
+}
+
+// Clusters groups clustered entities by individual, ordered by cluster ID.
+// Entities without a cluster are omitted.
+func Clusters(entities []Entity) []Cluster {
+	var clusters []Cluster
+	index := make(map[int]int)
+	for _, e := range entities {
+		if e.Cluster == 0 {
+			continue
+		}
+		i, ok := index[e.Cluster]
+		if !ok {
+			i = len(clusters)
+			index[e.Cluster] = i
+			clusters = append(clusters, Cluster{ID: e.Cluster})
+		}
+		c := &clusters[i]
+		if e.Type == "PERSON" && betterClusterName(personName(e), c.Name) {
+			c.Name = personName(e)
+		}
+		c.Entities = append(c.Entities, e)
+	}
+	sort.Slice(clusters, func(i, j int) bool { return clusters[i].ID < clusters[j].ID })
+	return clusters
+}
+
+// betterClusterName prefers names in "Given Surname" order over the
+// inverted "Surname, Given" form, then longer names.
+func betterClusterName(name, current string) bool {
+	inverted, currentInverted := strings.Contains(name, ","), strings.Contains(current, ",")
+	if current == "" || inverted != currentInverted {
+		return current == "" || !inverted
+	}
+	return len(name) > len(current)
+}
@@ -0,0 +1,67 @@
+package scanner
+
+import "testing"
+
+func TestCluster_SignatureBlock(t *testing.T) {
+	s := DefaultScanner(nil)
+	text := "Hallo zusammen,\n\nanbei die Unterlagen.\n\n" +
+		"Mit freundlichen Grüßen\nThomas Schmidt\nthomas.schmidt@firma.de\n" +
+		"Tel. +49 170 1234567\nIBAN DE89 3704 0044 0532 0130 00\n"
+	clusters := Clusters(s.Scan(text))
+	if len(clusters) != 1 {
+		t.Fatalf("want 1 cluster, got %+v", clusters)
+	}
+	c := clusters[0]
+	if c.Name != "Thomas Schmidt" {
+		t.Errorf("Name = %q, want %q", c.Name, "Thomas Schmidt")
+	}
+	for _, typ := range []string{"PERSON", "EMAIL", "PHONE", "IBAN"} {
+		if !hasEntityOfType(c.Entities, typ) {
+			t.Errorf("cluster lacks %s: %+v", typ, c.Entities)
+		}
+	}
+}
+
+func TestCluster_SameLineAndEmailLocalPart(t *testing.T) {
+	s := DefaultScanner(nil)
+	text := "Thomas Schmidt (Tel. +49 170 1234567) und Anna Koch (+49 171 7654321) waren da. " +
+		"Bitte Antwort an a.koch@firma.de."
+	entities := s.Scan(text)
+
+	want := map[string]string{
+		"+49 170 1234567": "Thomas Schmidt",
+		"+49 171 7654321": "Anna Koch",
+		"a.koch@firma.de": "Anna Koch",
+	}
+	names := make(map[int]string)
+	for _, c := range Clusters(entities) {
+		names[c.ID] = c.Name
+	}
+	for _, e := range entities {
+		if name, ok := want[e.Text]; ok && names[e.Cluster] != name {
+			t.Errorf("%q linked to %q, want %q", e.Text, names[e.Cluster], name)
+		}
+	}
+}
+
+func TestCluster_CoreferenceAndInvertedName(t *testing.T) {
+	s := DefaultScanner(nil)
+	entities := s.Scan("Teilnehmer: Schmidt, Thomas\n\nThomas Schmidt hat bestätigt. Herr Schmidt kommt.")
+	clusters := Clusters(entities)
+	if len(clusters) != 1 || countEntitiesOfType(clusters[0].Entities, "PERSON") != 3 {
+		t.Fatalf("want one cluster with three mentions, got %+v", clusters)
+	}
+	if clusters[0].Name != "Thomas Schmidt" {
+		t.Errorf("Name = %q, want the non-inverted form", clusters[0].Name)
+	}
+}
+
+func TestCluster_UnrelatedEntitiesStayUnlinked(t *testing.T) {
+	s := DefaultScanner(nil)
+	entities := s.Scan("Thomas Schmidt war hier.\n\nServer 192.168.1.10 ist down.")
+	for _, e := range entities {
+		if e.Type == "IP_ADDRESS" && e.Cluster != 0 {
+			t.Errorf("IP in another block linked to cluster %d", e.Cluster)
+		}
+	}
+}
@@ -0,0 +1,204 @@
+package scanner
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// --- PERSON coreference ---
+
+// corefHonorifics are forms of address that make a bare surname mention
+// unambiguous ("Herrn Koch" vs. "der Koch"). Case-insensitive.
+var corefHonorifics = map[string]bool{
+	"herr": true, "herrn": true, "frau": true, "dr": true, "prof": true,
+	"mr": true, "mrs": true, "ms": true, "miss": true,
+	"monsieur": true, "madame": true, "mme": true, "m": true,
+	"signor": true, "signora": true, "sig": true, "sr": true, "sra": true,
+	"señor": true, "señora": true, "meneer": true, "mevrouw": true,
+	"pan": true, "pani": true,
+}
+
+// corefVariant is a name part that refers back to a detected person.
+type corefVariant struct {
+	canonical string  // full name the part refers to; "" if shared by several people
+	person    string  // "given surname" key identifying the person
+	score     float64 // score of the strongest source mention
+	particles string  // surname particles to absorb in front, e.g. "de"
+}
+
+// resolveCoreferences finds later partial mentions of detected people: the
+// surname ("Schmidt", "Herrn Schmidt"), the given name ("Thomas") and
+// possessive or genitive forms ("Schmidt's", "Schmidts"). Each mention
+// becomes a PERSON entity whose Canonical field holds the full name, so the
+// redactor assigns it the same token. Single-word PERSON entities that match
+// a part of a longer name are linked the same way.
+//
+// entities must be sorted by Start and free of overlaps; the result is too.
+func resolveCoreferences(text string, entities []Entity) []Entity {
+	variants := collectCorefVariants(entities)
+	if len(variants) == 0 {
+		return entities
+	}
+
+	for i, e := range entities {
+		if e.Type != "PERSON" || e.Canonical != "" || strings.ContainsAny(e.Text, " \t,") {
+			continue
+		}
+		if v, ok := variants[e.Text]; ok && v.canonical != "" {
+			entities[i].Canonical = v.canonical
+		}
+	}
+
+	names := loadNames()
+	var found []Entity
+	next := 0 // index of the first entity that may overlap the current word
+	for _, w := range splitWords(text) {
+		for next < len(entities) && entities[next].End <= w.start {
+			next++
+		}
+		if next < len(entities) && entities[next].Start < w.end {
+			continue // already part of an entity
+		}
+
+		base, v, ok := matchCorefVariant(w.text, variants)
+		if !ok || embeddedInToken(text, w.start, w.end) {
+			continue
+		}
+		key := strings.ToLower(base)
+		ambiguous := names.stop[key] || names.first[key].ambiguous || names.last[key].ambiguous
+		if ambiguous && !precededByHonorific(text, w.start) {
+			continue
+		}
+
+		start, end := w.start, w.end
+		if strings.ContainsAny(w.text[len(base):], "'’") {
+			end = w.start + len(base) // leave the possessive suffix in place
+		}
+		if v.particles != "" {
+			prefix := v.particles + " "
+			if p := start - len(prefix); strings.HasSuffix(text[:start], prefix) && (p == 0 || !isLetterBefore(text, p)) {
+				start = p
+			}
+		}
+		found = append(found, Entity{
+			Start:     start,
+			End:       end,
+			Type:      "PERSON",
+			Text:      text[start:end],
+			Score:     math.Round(v.score*0.9*100) / 100,
+			Detector:  "coreference",
+			Canonical: v.canonical,
+		})
+	}
+	if len(found) == 0 {
+		return entities
+	}
+
+	merged := append(entities, found...)
+	sort.Slice(merged, func(i, j int) bool { return merged[i].Start < merged[j].Start })
+	return merged
+}
+
+// collectCorefVariants indexes the surname and given name of every
+// multi-word PERSON entity by their exact spelling.
+func collectCorefVariants(entities []Entity) map[string]corefVariant {
+	variants := make(map[string]corefVariant)
+	add := func(part string, v corefVariant) {
+		if utf8.RuneCountInString(part) < 2 || !isNameShaped(part) {
+			return
+		}
+		prev, ok := variants[part]
+		switch {
+		case !ok:
+			variants[part] = v
+		case prev.person != v.person:
+			// Shared by two people ("Anna Schmidt", "Thomas Schmidt"): still
+			// a person, but not attributable to either.
+			prev.canonical, prev.person = "", ""
+			prev.score = max(prev.score, v.score)
+			variants[part] = prev
+		}
+	}
+
+	for _, e := range entities {
+		if e.Type != "PERSON" || e.Canonical != "" {
+			continue
+		}
+		given, surname, particles := splitPersonName(e.Text)
+		if given == "" || surname == "" {
+			continue
+		}
+		person := strings.ToLower(given + " " + surname)
+		add(surname, corefVariant{canonical: e.Text, person: person, score: e.Score, particles: particles})
+		add(given, corefVariant{canonical: e.Text, person: person, score: e.Score})
+	}
+	return variants
+}
+
+// splitPersonName returns the given name, surname and any surname particles
+// of a full name, in either "Given [Middle] [particles] Surname" or
+// "Surname, Given" order. Initials are skipped.
+func splitPersonName(name string) (given, surname, particles string) {
+	if before, after, ok := strings.Cut(name, ","); ok {
+		s, g := splitWords(before), splitWords(after)
+		if len(s) == 0 || len(g) == 0 {
+			return "", "", ""
+		}
+		return g[0].text, s[len(s)-1].text, ""
+	}
+
+	var parts, pending []string
+	for _, w := range splitWords(name) {
+		switch {
+		case surnameParticles[w.text]:
+			pending = append(pending, w.text)
+		case utf8.RuneCountInString(w.text) == 1:
+			// initial
+		default:
+			parts = append(parts, w.text)
+			particles = strings.Join(pending, " ")
+			pending = nil
+		}
+	}
+	if len(parts) < 2 {
+		return "", "", ""
+	}
+	return parts[0], parts[len(parts)-1], particles
+}
+
+// matchCorefVariant matches a word against the variant index, directly or
+// as a possessive ("Schmidt's", "Hans'") or genitive ("Schmidts") form, and
+// returns the base name.
+func matchCorefVariant(w string, variants map[string]corefVariant) (string, corefVariant, bool) {
+	if v, ok := variants[w]; ok {
+		return w, v, true
+	}
+	for _, suffix := range []string{"'s", "’s", "'", "’", "s"} {
+		base, ok := strings.CutSuffix(w, suffix)
+		if !ok {
+			continue
+		}
+		if v, ok := variants[base]; ok {
+			return base, v, true
+		}
+	}
+	return "", corefVariant{}, false
+}
+
+// precededByHonorific reports whether the word at start follows a form of
+// address such as "Herrn" or "Mrs." on the same line.
+func precededByHonorific(text string, start int) bool {
+	before := strings.TrimRight(text[:start], " \t")
+	before = strings.TrimSuffix(before, ".")
+	i := strings.LastIndexAny(before, " \t\n\r")
+	return corefHonorifics[strings.ToLower(before[i+1:])]
+}
+
+// isLetterBefore reports whether the rune ending at offset i is a letter.
+func isLetterBefore(text string, i int) bool {
+	r, _ := utf8.DecodeLastRuneInString(text[:i])
+	return unicode.IsLetter(r)
+}
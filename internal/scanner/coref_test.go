@@ -0,0 +1,66 @@
+package scanner
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestCoreference_PartialMentions(t *testing.T) {
+	s := DefaultScanner(nil)
+	text := "Sehr geehrter Herr Thomas Schmidt,\n" +
+		"wie besprochen hat Herrn Schmidts Anwalt angerufen. Schmidt's Frau und Thomas kommen morgen."
+	entities := s.Scan(text)
+
+	for _, want := range []string{"Schmidts", "Schmidt", "Thomas"} {
+		found := false
+		for _, e := range entities {
+			if e.Type == "PERSON" && e.Text == want && e.Detector == "coreference" {
+				if e.Canonical != "Thomas Schmidt" {
+					t.Errorf("%q: Canonical = %q, want %q", want, e.Canonical, "Thomas Schmidt")
+				}
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("coreference mention %q not found, got %v", want, entities)
+		}
+	}
+}
+
+func TestCoreference_Particles(t *testing.T) {
+	s := DefaultScanner(nil)
+	entities := s.Scan("Gestern rief Anna Maria de Vries an. Später schrieb Frau de Vries.")
+	if !hasEntityWithText(entities, "PERSON", "de Vries") {
+		t.Errorf("surname with particle not resolved, got %v", entities)
+	}
+}
+
+func TestCoreference_AmbiguousNeedsHonorific(t *testing.T) {
+	s := DefaultScanner(nil)
+	entities := s.Scan("Anna Koch kam. Der Koch kocht. Frau Koch lacht.")
+	if n := countEntitiesOfType(entities, "PERSON"); n != 2 {
+		t.Errorf("want 2 PERSON entities (full name and Frau Koch), got %d: %v", n, entities)
+	}
+}
+
+func TestCoreference_SharedSurnameIsUnattributed(t *testing.T) {
+	s := DefaultScanner(nil)
+	entities := s.Scan("Anna Schmidt und Thomas Schmidt sind verheiratet. Schmidt kam später.")
+	for _, e := range entities {
+		if e.Detector == "coreference" && e.Text == "Schmidt" {
+			if e.Canonical != "" {
+				t.Errorf("shared surname attributed to %q", e.Canonical)
+			}
+			return
+		}
+	}
+	t.Errorf("shared surname not detected, got %v", entities)
+}
+
+func TestCoreference_RespectsAllowlist(t *testing.T) {
+	s := DefaultScanner([]*regexp.Regexp{regexp.MustCompile(`^Thomas Schmidt$`)})
+	entities := s.Scan("Thomas Schmidt rief an. Schmidt kommt morgen.")
+	if n := countEntitiesOfType(entities, "PERSON"); n != 0 {
+		t.Errorf("allowlisted name must not be resolved, got %v", entities)
+	}
+}
@@ -0,0 +1,70 @@
+package scanner
+
+import "testing"
+
+func TestDeviceID_TruePositives(t *testing.T) {
+	s := DefaultScanner(nil)
+	cases := []struct {
+		name    string
+		input   string
+		want    string
+		subtype string
+	}{
+		// IMEI / IMEISV / MEID
+		{"IMEI with label", "IMEI: 352099001761481", "352099001761481", "imei"},
+		{"IMEI key=value", "device imei=490154203237518 registered", "490154203237518", "imei"},
+		{"IMEI grouped standalone", "Gerät 35-209900-176148-1 gesperrt", "35-209900-176148-1", "imei"},
+		{"IMEISV", "IMEISV: 3520990017614823", "3520990017614823", "imeisv"},
+		{"MEID", "MEID: A10000009296F2", "A10000009296F2", "meid"},
+		// Advertising and platform IDs
+		{"IDFA JSON", `{"idfa": "6D92078A-8246-4BA4-AE5B-76104861E7DC"}`, "6D92078A-8246-4BA4-AE5B-76104861E7DC", "advertising_id"},
+		{"GAID", "GAID=38400000-8cf0-11bd-b23e-10b96e40000d", "38400000-8cf0-11bd-b23e-10b96e40000d", "advertising_id"},
+		{"advertising_id log", "advertising_id: 38400000-8cf0-11bd-b23e-10b96e40000d", "38400000-8cf0-11bd-b23e-10b96e40000d", "advertising_id"},
+		{"Android ID", "android_id=9774d56d682e549c", "9774d56d682e549c", "android_id"},
+		// SIM
+		{"ICCID with label", "ICCID: 8944500102198304826", "8944500102198304826", "iccid"},
+		{"ICCID standalone", "SIM 8944500102198304826 aktiviert", "8944500102198304826", "iccid"},
+		// Session cookies
+		{"JSESSIONID", "Cookie: JSESSIONID=1A530637289A03B07199A44E8D531427; Path=/", "1A530637289A03B07199A44E8D531427", "session_cookie"},
+		{"PHPSESSID", "PHPSESSID=el4ukv0kqbvoirg7nkp4dncpk3", "el4ukv0kqbvoirg7nkp4dncpk3", "session_cookie"},
+		{"Google Analytics", "_ga=GA1.2.1234567890.1700000000", "GA1.2.1234567890.1700000000", "session_cookie"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			entities := s.Scan(tc.input)
+			for _, e := range entities {
+				if e.Type == "DEVICE_ID" && e.Text == tc.want {
+					if e.Subtype != tc.subtype {
+						t.Errorf("subtype = %q, want %q", e.Subtype, tc.subtype)
+					}
+					return
+				}
+			}
+			t.Errorf("DEVICE_ID not found in %q: wanted %q, got %v", tc.input, tc.want, entities)
+		})
+	}
+}
+
+func TestDeviceID_TrueNegatives(t *testing.T) {
+	s := DefaultScanner(nil)
+	cases := []struct {
+		name  string
+		input string
+	}{
+		{"IMEI failing Luhn", "IMEI: 352099001761482"},
+		{"bare UUID", "request 6D92078A-8246-4BA4-AE5B-76104861E7DC failed"},
+		{"zeroed advertising ID", "idfa=00000000-0000-0000-0000-000000000000"},
+		{"ICCID failing Luhn", "8944500102198304827"},
+		{"short cookie value", "sessionid=abc"},
+		{"unknown cookie", "theme=dark-mode-enabled"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			for _, e := range s.Scan(tc.input) {
+				if e.Type == "DEVICE_ID" {
+					t.Errorf("DEVICE_ID false positive in %q: got %v", tc.input, e)
+				}
+			}
+		})
+	}
+}
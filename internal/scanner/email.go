@@ -0,0 +1,292 @@
+package scanner
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// --- EMAIL ---
+
+// emailAtext are the characters of an unquoted local part (RFC 5322 atext,
+// extended to any letter, mark or digit by RFC 6531) without the dot.
+// "=", "/", "`", "|", "{" and "}" are left out: they are valid but in
+// running text far more often delimit an address than belong to it.
+const emailAtext = `\p{L}\p{M}\p{N}!#$%&'*+\-?^_~`
+
+// emailDomain matches a domain of letter-digit-hyphen labels in any script,
+// including punycode labels, or an address literal ("[192.0.2.1]").
+const emailDomain = `(?:(?:[\p{L}\p{M}\p{N}](?:[\p{L}\p{M}\p{N}\-]*[\p{L}\p{M}\p{N}])?\.)+(?:xn--[a-z0-9\-]+|\p{L}[\p{L}\p{M}]+)|\[(?:\d{1,3}(?:\.\d{1,3}){3}|IPv6:[0-9A-Fa-f:.]+)\])`
+
+const (
+	// emailDotAtom matches an address with an unquoted local part.
+	emailDotAtom = `[\p{L}\p{N}_][` + emailAtext + `]*(?:\.[` + emailAtext + `]+)*@` + emailDomain
+	// emailQuoted matches an address with a quoted local part.
+	emailQuoted = `"(?:[^"\\\r\n]|\\.)+"@` + emailDomain
+)
+
+// emailExactRe matches a string that is exactly one address.
+var emailExactRe = regexp.MustCompile(`^(?:` + emailDotAtom + `|` + emailQuoted + `)$`)
+
+// emailScanners detects email addresses as defined by RFC 5321 and RFC 6531:
+// dot-atom local parts in any script ("müller@", "иван@", "张伟@"),
+// quoted local parts ("\"john doe\"@"), subaddresses ("anna+news@") and
+// internationalized or punycode domains (müller.de, xn--mller-kva.de).
+// Dots may not lead, trail or repeat in the local part, and every domain
+// label is checked against the length and hyphen rules of RFC 5890.
+func emailScanners() []Scanner {
+	return []Scanner{
+		NewRegexScanner(regexp.MustCompile(emailDotAtom), "EMAIL", 0.99,
+			WithValidator(validateEmail),
+			WithContextValidator(emailBoundary),
+		),
+		NewRegexScanner(regexp.MustCompile(emailQuoted), "EMAIL", 0.95,
+			WithValidator(validateEmail),
+		),
+	}
+}
+
+// emailBoundary rejects a match that starts inside a longer run of local
+// part characters, such as "b@x.de" in the invalid "a..b@x.de". A quote
+// before the address delimits it.
+func emailBoundary(text string, start, end int) bool {
+	if start == 0 {
+		return true
+	}
+	r, _ := utf8.DecodeLastRuneInString(text[:start])
+	return !(r == '.' || r == '@' || strings.ContainsRune("!#$%&*+-?^_~=/", r) || unicode.IsLetter(r) || unicode.IsDigit(r))
+}
+
+// validateEmail checks the length limits of RFC 5321 (64 octets for the
+// local part, 253 for the domain in ASCII form) and every domain label.
+func validateEmail(s string) bool {
+	at := strings.LastIndexByte(s, '@')
+	local, domain := s[:at], s[at+1:]
+	if len(local) > 64 {
+		return false
+	}
+	if strings.HasPrefix(domain, "[") {
+		return true
+	}
+	ascii, ok := asciiDomain(domain)
+	if !ok || len(ascii) > 253 {
+		return false
+	}
+	labels := strings.Split(ascii, ".")
+	tld := labels[len(labels)-1]
+	return strings.Trim(tld, "0123456789") != ""
+}
+
+// asciiDomain converts a domain to its lowercase ASCII form, encoding
+// non-ASCII labels with punycode, and reports whether every label is valid:
+// 1–63 octets, no leading or trailing hyphen, no hyphens in the third and
+// fourth position unless the label is a punycode label that decodes to a
+// non-ASCII name.
+func asciiDomain(domain string) (string, bool) {
+	labels := strings.Split(strings.ToLower(domain), ".")
+	for i, label := range labels {
+		if !isASCII(label) {
+			label = "xn--" + punycodeEncode([]rune(label))
+		} else if strings.HasPrefix(label, "xn--") {
+			decoded, ok := punycodeDecode(label[4:])
+			if !ok || isASCII(string(decoded)) || punycodeEncode(decoded) != label[4:] {
+				return "", false
+			}
+		} else if len(label) >= 4 && label[2:4] == "--" {
+			return "", false
+		}
+		if label == "" || len(label) > 63 || label[0] == '-' || label[len(label)-1] == '-' {
+			return "", false
+		}
+		labels[i] = label
+	}
+	return strings.Join(labels, "."), true
+}
+
+// normalizeEmail returns the normalized form of an address: the local part
+// as written and the domain lowercased in its ASCII (punycode) form, so
+// that "Max@Müller.DE" and "Max@xn--mller-kva.de" compare equal.
+func normalizeEmail(s string) string {
+	at := strings.LastIndexByte(s, '@')
+	if at < 0 {
+		return s
+	}
+	domain := s[at+1:]
+	if !strings.HasPrefix(domain, "[") {
+		if ascii, ok := asciiDomain(domain); ok {
+			domain = ascii
+		}
+	}
+	return s[:at+1] + domain
+}
+
+// normalizeEmails sets Normalized on every EMAIL entity.
+func normalizeEmails(entities []Entity) []Entity {
+	for i, e := range entities {
+		if e.Type == "EMAIL" {
+			entities[i].Normalized = normalizeEmail(e.Text)
+		}
+	}
+	return entities
+}
+
+// renormalizeEmails recomputes Normalized for addresses found only after
+// deobfuscation whose original spelling is itself a valid address, such as
+// a Greek local part that canonicalization read as look-alike letters.
+// Other deobfuscated addresses ("john [at] example [dot] com") keep the
+// form normalized from the canonical text.
+func renormalizeEmails(entities []Entity) []Entity {
+	for i, e := range entities {
+		if e.Type == "EMAIL" && e.Deobfuscated && emailExactRe.MatchString(e.Text) && validateEmail(e.Text) {
+			entities[i].Normalized = normalizeEmail(e.Text)
+		}
+	}
+	return entities
+}
+
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
+
+// Punycode (RFC 3492) parameters.
+const (
+	punyBase        = 36
+	punyTMin        = 1
+	punyTMax        = 26
+	punySkew        = 38
+	punyDamp        = 700
+	punyInitialBias = 72
+	punyInitialN    = 128
+)
+
+// punycodeEncode encodes a label with punycode, without the "xn--" prefix.
+func punycodeEncode(label []rune) string {
+	var out strings.Builder
+	for _, r := range label {
+		if r < utf8.RuneSelf {
+			out.WriteRune(r)
+		}
+	}
+	basic := out.Len()
+	if basic > 0 {
+		out.WriteByte('-')
+	}
+	n, delta, bias := rune(punyInitialN), 0, punyInitialBias
+	for h := basic; h < len(label); {
+		m := rune(unicode.MaxRune)
+		for _, r := range label {
+			if r >= n && r < m {
+				m = r
+			}
+		}
+		delta += int(m-n) * (h + 1)
+		n = m
+		for _, r := range label {
+			if r < n {
+				delta++
+			}
+			if r != n {
+				continue
+			}
+			q := delta
+			for k := punyBase; ; k += punyBase {
+				t := punyThreshold(k, bias)
+				if q < t {
+					break
+				}
+				out.WriteByte(punyDigit(t + (q-t)%(punyBase-t)))
+				q = (q - t) / (punyBase - t)
+			}
+			out.WriteByte(punyDigit(q))
+			bias = punyAdapt(delta, h+1, h == basic)
+			delta = 0
+			h++
+		}
+		delta++
+		n++
+	}
+	return out.String()
+}
+
+// punycodeDecode decodes a punycode label without the "xn--" prefix.
+func punycodeDecode(s string) ([]rune, bool) {
+	var out []rune
+	pos := 0
+	if b := strings.LastIndexByte(s, '-'); b >= 0 {
+		out = []rune(s[:b])
+		pos = b + 1
+	}
+	n, i, bias := rune(punyInitialN), 0, punyInitialBias
+	for pos < len(s) {
+		oldi, w := i, 1
+		for k := punyBase; ; k += punyBase {
+			if pos >= len(s) {
+				return nil, false
+			}
+			digit := punyDigitValue(s[pos])
+			pos++
+			if digit < 0 || i > (1<<30)/max(w, 1) {
+				return nil, false
+			}
+			i += digit * w
+			t := punyThreshold(k, bias)
+			if digit < t {
+				break
+			}
+			w *= punyBase - t
+		}
+		bias = punyAdapt(i-oldi, len(out)+1, oldi == 0)
+		n += rune(i / (len(out) + 1))
+		i %= len(out) + 1
+		if n > unicode.MaxRune || n < punyInitialN {
+			return nil, false
+		}
+		out = append(out[:i], append([]rune{n}, out[i:]...)...)
+		i++
+	}
+	return out, true
+}
+
+func punyThreshold(k, bias int) int {
+	return min(max(k-bias, punyTMin), punyTMax)
+}
+
+func punyAdapt(delta, points int, first bool) int {
+	if first {
+		delta /= punyDamp
+	} else {
+		delta /= 2
+	}
+	delta += delta / points
+	k := 0
+	for delta > ((punyBase-punyTMin)*punyTMax)/2 {
+		delta /= punyBase - punyTMin
+		k += punyBase
+	}
+	return k + (punyBase-punyTMin+1)*delta/(delta+punySkew)
+}
+
+func punyDigit(d int) byte {
+	if d < 26 {
+		return byte('a' + d)
+	}
+	return byte('0' + d - 26)
+}
+
+func punyDigitValue(c byte) int {
+	switch {
+	case c >= 'a' && c <= 'z':
+		return int(c - 'a')
+	case c >= 'A' && c <= 'Z':
+		return int(c - 'A')
+	case c >= '0' && c <= '9':
+		return int(c-'0') + 26
+	}
+	return -1
+}
@@ -0,0 +1,72 @@
+package scanner
+
+import "testing"
+
+func TestEmail_International(t *testing.T) {
+	s := DefaultScanner(nil)
+	cases := []struct {
+		name, input, want, normalized string
+	}{
+		{"IDN domain", "Mail an max@müller.de bitte", "max@müller.de", "max@xn--mller-kva.de"},
+		{"punycode domain", "Mail an max@xn--mller-kva.de bitte", "max@xn--mller-kva.de", "max@xn--mller-kva.de"},
+		{"uppercase domain", "Write to Anna.Berger@Firma.DE.", "Anna.Berger@Firma.DE", "Anna.Berger@firma.de"},
+		{"Cyrillic", "Пишите: иван@пример.рф", "иван@пример.рф", "иван@xn--e1afmkfd.xn--p1ai"},
+		{"Greek local part", "email: γιώργος@example.gr", "γιώργος@example.gr", "γιώργος@example.gr"},
+		{"CJK", "联系 张伟@例子.中国 谢谢", "张伟@例子.中国", "张伟@xn--fsqu00a.xn--fiqs8s"},
+		{"subaddress", "anna+newsletter@firma.de", "anna+newsletter@firma.de", "anna+newsletter@firma.de"},
+		{"apostrophe", "Contact o'brien@firma.ie today", "o'brien@firma.ie", "o'brien@firma.ie"},
+		{"quoted local part", `Write to "john doe"@example.com`, `"john doe"@example.com`, `"john doe"@example.com`},
+		{"address literal", "root@[192.0.2.1]", "root@[192.0.2.1]", "root@[192.0.2.1]"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			for _, e := range s.Scan(tc.input) {
+				if e.Type == "EMAIL" && e.Text == tc.want {
+					if e.Normalized != tc.normalized {
+						t.Errorf("Normalized = %q, want %q", e.Normalized, tc.normalized)
+					}
+					return
+				}
+			}
+			t.Fatalf("EMAIL %q not found in %q, got %v", tc.want, tc.input, s.Scan(tc.input))
+		})
+	}
+}
+
+func TestEmail_Invalid(t *testing.T) {
+	s := DefaultScanner(nil)
+	for _, input := range []string{
+		"a..b@example.com",
+		".anna@example.com",
+		"anna.@example.com",
+		"anna@-firma.de",
+		"anna@firma-.de",
+		"anna@ab--cd.de",
+		"anna@xn--zz.de",
+		"anna@example.123",
+	} {
+		for _, e := range s.Scan(input) {
+			if e.Type == "EMAIL" {
+				t.Errorf("invalid address %q detected: %v", input, e)
+			}
+		}
+	}
+}
+
+func TestPunycode(t *testing.T) {
+	for unicode, ascii := range map[string]string{
+		"müller": "mller-kva",
+		"пример": "e1afmkfd",
+		"中国":     "fiqs8s",
+		"bücher": "bcher-kva",
+		"españa": "espaa-rta",
+		"ドメイン名例": "eckwd4c7cu47r2wf",
+	} {
+		if got := punycodeEncode([]rune(unicode)); got != ascii {
+			t.Errorf("punycodeEncode(%q) = %q, want %q", unicode, got, ascii)
+		}
+		if got, ok := punycodeDecode(ascii); !ok || string(got) != unicode {
+			t.Errorf("punycodeDecode(%q) = %q, %v, want %q", ascii, string(got), ok, unicode)
+		}
+	}
+}
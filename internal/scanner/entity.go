@@ -3,10 +3,45 @@ package scanner
 // Entity represents a detected PII entity in text.
 // JSON shape matches the existing aegis-software frontend contract.
 type Entity struct {
-	Start    int     `json:"start"`    // byte offset in text
-	End      int     `json:"end"`      // byte offset (exclusive)
-	Type     string  `json:"type"`     // "PERSON", "EMAIL", "PHONE", etc.
-	Text     string  `json:"text"`     // matched substring
-	Score    float64 `json:"score"`    // confidence (0.0–1.0)
-	Detector string  `json:"detector"` // detection method, e.g. "regex"
+	Start    int     `json:"start"`             // byte offset in text
+	End      int     `json:"end"`               // byte offset (exclusive)
+	Type     string  `json:"type"`              // "PERSON", "EMAIL", "PHONE", etc.
+	Text     string  `json:"text"`              // matched substring
+	Score    float64 `json:"score"`             // confidence (0.0–1.0)
+	Detector string  `json:"detector"`          // detection method, e.g. "regex"
+	Subtype  string  `json:"subtype,omitempty"` // finer classification, e.g. "imei"
+	// Canonical is the full text of the entity a partial mention refers to,
+	// e.g. "Thomas Schmidt" for a later "Schmidt". Empty for primary mentions.
+	Canonical string `json:"canonical,omitempty"`
+	// Cluster links entities that belong to the same individual (see
+	// Clusters). 0 means the entity is not linked to a person.
+	Cluster int `json:"cluster,omitempty"`
+	// Deobfuscated is set when the entity was only found after undoing
+	// obfuscation (zero-width characters, look-alike letters, "[at]") or,
+	// in transcript mode, converting spoken numbers to digits.
+	Deobfuscated bool `json:"deobfuscated,omitempty"`
+	// TestData is set when the value is a well-known test or specimen value
+	// ("4111 1111 1111 1111", "Max Mustermann") or lies in a range reserved
+	// for documentation (example.com, 192.0.2.0/24). Its score is lowered.
+	TestData bool `json:"test_data,omitempty"`
+	// Role is the role of a PERSON implied by the word that introduced the
+	// name ("Patientin", "Oberarzt", "Zeuge"), one of the Role* constants.
+	Role string `json:"role,omitempty"`
+	// Link groups the parts of one compound identifier, such as an account
+	// number and the routing code of its bank, so they are redacted
+	// together. 0 means the entity is not linked.
+	Link int `json:"link,omitempty"`
+	// Amount is the parsed value and currency of a FINANCIAL amount; nil
+	// for other entities and for FINANCIAL codes such as BICs.
+	Amount *Amount `json:"amount,omitempty"`
+	// Address is the decomposition of an ADDRESS into street, house number,
+	// postcode, city and country; nil for other entities.
+	Address *Address `json:"address,omitempty"`
+	// Parts are the sensitive components of a URL (credentials, path
+	// segments, query and fragment values) as entities of their own type,
+	// with offsets into the full text. Empty for other entities.
+	Parts []Entity `json:"parts,omitempty"`
+	// Normalized is the normalized form of an EMAIL: the domain lowercased
+	// in its ASCII (punycode) form. Empty for other entities.
+	Normalized string `json:"normalized,omitempty"`
 }
@@ -0,0 +1,147 @@
+package scanner
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"embed"
+	"fmt"
+	"io"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// gazetteerData holds the word lists used by dictionary-based scanners.
+// Every file is gzip-compressed UTF-8 text with one record per line; lines
+// starting with '#' are comments. To edit a list, decompress it with zcat,
+// change it and recompress with "gzip -n -9" so the output is reproducible.
+//
+//go:embed data/*.gz
+var gazetteerData embed.FS
+
+// readGazetteer returns the non-empty, non-comment lines of an embedded
+// gazetteer file. The data is fixed at build time, so a read error is a
+// programming error and panics.
+func readGazetteer(name string) []string {
+	raw, err := gazetteerData.ReadFile("data/" + name)
+	if err != nil {
+		panic(fmt.Sprintf("scanner: gazetteer %s: %v", name, err))
+	}
+	zr, err := gzip.NewReader(bytes.NewReader(raw))
+	if err != nil {
+		panic(fmt.Sprintf("scanner: gazetteer %s: %v", name, err))
+	}
+	data, err := io.ReadAll(zr)
+	if err != nil {
+		panic(fmt.Sprintf("scanner: gazetteer %s: %v", name, err))
+	}
+
+	var lines []string
+	sc := bufio.NewScanner(bytes.NewReader(data))
+	for sc.Scan() {
+		line := strings.TrimRight(sc.Text(), "\r")
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// word is a run of letters in text, with byte offsets. Inner hyphens and
+// apostrophes are part of the word (Baden-Württemberg, Côte d'Azur).
+type word struct {
+	text  string
+	start int
+	end   int
+}
+
+// splitWords tokenizes text into words for gazetteer lookups.
+func splitWords(text string) []word {
+	var words []word
+	start := -1
+	for i := 0; i < len(text); {
+		r, size := utf8.DecodeRuneInString(text[i:])
+		switch {
+		case unicode.IsLetter(r) || unicode.Is(unicode.Mn, r):
+			if start < 0 {
+				start = i
+			}
+		case start >= 0 && (r == '-' || r == '\'' || r == '’') && i+size < len(text):
+			next, _ := utf8.DecodeRuneInString(text[i+size:])
+			if !unicode.IsLetter(next) {
+				words = append(words, word{text: text[start:i], start: start, end: i})
+				start = -1
+			}
+		default:
+			if start >= 0 {
+				words = append(words, word{text: text[start:i], start: start, end: i})
+				start = -1
+			}
+		}
+		i += size
+	}
+	if start >= 0 {
+		words = append(words, word{text: text[start:], start: start, end: len(text)})
+	}
+	return words
+}
+
+// wordsAdjacent reports whether consecutive words are separated only by
+// blanks on the same line, optionally after an abbreviation dot ("St. Gallen").
+func wordsAdjacent(text string, words []word) bool {
+	for i := 1; i < len(words); i++ {
+		sep := strings.TrimPrefix(text[words[i-1].end:words[i].start], ".")
+		if sep == "" || strings.Trim(sep, " \t") != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// wordsKey builds the lookup key for a run of words: lowercased and joined
+// by single spaces, so "St. Gallen" and "st gallen" share a key.
+func wordsKey(words []word) string {
+	parts := make([]string, len(words))
+	for i, w := range words {
+		parts[i] = strings.ToLower(w.text)
+	}
+	return strings.Join(parts, " ")
+}
+
+// nameKey builds the lookup key for a gazetteer entry or caller-supplied name.
+func nameKey(name string) string {
+	return wordsKey(splitWords(name))
+}
+
+// isUpperInitial reports whether s starts with an uppercase letter.
+func isUpperInitial(s string) bool {
+	r, _ := utf8.DecodeRuneInString(s)
+	return unicode.IsUpper(r)
+}
+
+// embeddedInToken reports whether the span [start:end) is glued to a larger
+// token such as an email address, domain, hashtag or identifier, in which
+// case dictionary scanners must not match it.
+func embeddedInToken(text string, start, end int) bool {
+	if start > 0 {
+		prev, _ := utf8.DecodeLastRuneInString(text[:start])
+		if strings.ContainsRune("@./#_\\", prev) || unicode.IsDigit(prev) {
+			return true
+		}
+	}
+	if end < len(text) {
+		next, size := utf8.DecodeRuneInString(text[end:])
+		if strings.ContainsRune("@_/\\", next) || unicode.IsDigit(next) {
+			return true
+		}
+		if next == '.' && end+size < len(text) {
+			after, _ := utf8.DecodeRuneInString(text[end+size:])
+			if unicode.IsLetter(after) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,151 @@
+package scanner
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// --- LEGAL_REFERENCE ---
+
+// courtRegisters are the register codes of German and Austrian case
+// numbers: civil (O, S, U, C), labour (Ca, Sa, AZR), social (AS, KR),
+// criminal and prosecution (StR, Ss, Qs, Ks, KLs, Ls, Ds, Cs, Js), family
+// (F, UF, WF), constitutional (BvR, BvL, BvE), federal civil (ZR, ZB),
+// administrative (K, L, A, B) and Austrian registers (Ob, Os, R, Cg, Hv).
+const courtRegisters = `O|S|U|C|W|T|OH|Ca|Sa|AZR|AS|KR|StR|Ss|Qs|Ks|KLs|Ls|Ds|Cs|Js|OWi|F|UF|WF|BvR|BvL|BvE|BvQ|ZR|ZB|AR|K|L|A|B|Ob|Os|R|Cg|Hv`
+
+// distinctiveRegisters are the register codes that do not also occur as
+// units, abbreviations or words ("2 L", "ca.", "Sa."); only case numbers
+// with one of them are detected without a keyword.
+const distinctiveRegisters = `AZR|StR|Ss|Qs|Ks|KLs|Ls|Ds|Cs|Js|OWi|UF|WF|BvR|BvL|BvE|BvQ|ZR|ZB|Ob|Os|Cg|Hv`
+
+// caseNumber matches a German or Austrian case number (Aktenzeichen,
+// Geschäftszahl): chamber or senate, register, running number and year,
+// with the Austrian check letter: "3 O 123/24", "VI ZR 123/21",
+// "2 BvR 1234/19", "1 Ob 123/21x".
+const caseNumber = `(?:[IVX]{1,5}|\d{1,3})[ \t]+(?:` + courtRegisters + `)[ \t]+\d{1,6}/\d{2}(?:\d{2})?[a-z]?`
+
+// distinctiveCaseNumber is a caseNumber with one of distinctiveRegisters.
+const distinctiveCaseNumber = `(?:[IVX]{1,5}|\d{1,3})[ \t]+(?:` + distinctiveRegisters + `)[ \t]+\d{1,6}/\d{2}(?:\d{2})?[a-z]?`
+
+// registerCourtContext matches the words that place a register number at a
+// register court: "Amtsgericht Charlottenburg", "Registergericht", "AG München".
+var registerCourtContext = regexp.MustCompile(`(?i)\b(?:Amtsgericht|Registergericht|Handelsregister|Vereinsregister|Genossenschaftsregister|Partnerschaftsregister|AG\s+[A-ZÄÖÜ])`)
+
+// legalReferenceScanners returns scanners for references to court
+// proceedings and public registers: case numbers, ECLIs, commercial
+// register and Firmenbuch numbers, land register entries and EU trademark
+// and patent numbers. Like taxNumberScanners, formats that are not
+// distinctive on their own need a keyword.
+func legalReferenceScanners() []Scanner {
+	return []Scanner{
+		// DE/AT: case number (context-triggered): "Az.: 3 O 123/24"
+		NewRegexScanner(
+			regexp.MustCompile(`(?i:\bAktenzeichen|\bAz\.|\bGeschäftszeichen|\bGZ|\bGz\.|\bGeschäftszahl|\bGZl\.?|\bcase\s+(?:No\.?|number))[:\s]+(`+caseNumber+`)\b`),
+			"LEGAL_REFERENCE", 0.95,
+			WithExtractGroup(1),
+			WithSubtype("case_number"),
+		),
+		// DE/AT: case number standalone, distinctive register code required
+		NewRegexScanner(
+			regexp.MustCompile(`\b`+distinctiveCaseNumber+`\b`),
+			"LEGAL_REFERENCE", 0.85,
+			WithValidator(validateCaseYear),
+			WithSubtype("case_number"),
+		),
+		// European Case Law Identifier: ECLI:DE:BGH:2021:120321UVIZR123.21.0
+		NewRegexScanner(
+			regexp.MustCompile(`\bECLI:[A-Z]{2}:[A-Z0-9]{1,7}:\d{4}:[A-Z0-9.]{0,24}[A-Z0-9]\b`),
+			"LEGAL_REFERENCE", 0.95,
+			WithValidator(validateECLI),
+			WithSubtype("ecli"),
+		),
+		// DE: Handelsregister (context-triggered): "Amtsgericht Charlottenburg, HRB 12345 B"
+		NewRegexScanner(
+			regexp.MustCompile(`\b(?:HR[AB]|GnR|PR|VR|GsR)[ \t]?\d{1,6}(?:[ \t][A-Z]{1,2})?\b`),
+			"LEGAL_REFERENCE", 0.95,
+			WithContextValidator(registerCourtNearby),
+			WithSubtype("commercial_register"),
+		),
+		// DE: HRA/HRB standalone
+		NewRegexScanner(
+			regexp.MustCompile(`\bHR[AB][ \t]?\d{1,6}(?:[ \t][A-Z]{1,2})?\b`),
+			"LEGAL_REFERENCE", 0.90,
+			WithSubtype("commercial_register"),
+		),
+		// AT: Firmenbuchnummer: FN 123456a
+		NewRegexScanner(
+			regexp.MustCompile(`\bFN[ \t]?\d{1,6}[ \t]?[a-z]\b`),
+			"LEGAL_REFERENCE", 0.90,
+			WithSubtype("firmenbuch"),
+		),
+		// DE: Grundbuch (context-triggered): "Grundbuch von Mitte Blatt 1234"
+		NewRegexScanner(
+			regexp.MustCompile(`(?i:\bGrundbuch(?:[ \t]+von[ \t]+[\p{L}\-]+(?:[ \t]+[\p{L}\-]+)?)?,?[ \t]+Blatt|\bGrundbuchblatt)(?:[ \t]+Nr\.?)?[:\s]+(\d{1,6}[a-z]?)\b`),
+			"LEGAL_REFERENCE", 0.90,
+			WithExtractGroup(1),
+			WithSubtype("land_register"),
+		),
+		// DE: cadastral parcel (context-triggered): "Flur 3, Flurstück 123/4"
+		NewRegexScanner(
+			regexp.MustCompile(`(?i:\bFlurstück(?:s?-?Nr\.?)?)[:\s]+(\d{1,5}(?:/\d{1,4})?)\b`),
+			"LEGAL_REFERENCE", 0.85,
+			WithExtractGroup(1),
+			WithSubtype("land_register"),
+		),
+		// AT: Grundbuch: "EZ 1234 KG 01004 Innere Stadt"
+		NewRegexScanner(
+			regexp.MustCompile(`(?i:\bEZ|\bEinlagezahl)[:\s]+(\d{1,5}[ \t]+(?:der[ \t]+)?KG[ \t]+\d{5})\b`),
+			"LEGAL_REFERENCE", 0.90,
+			WithExtractGroup(1),
+			WithSubtype("land_register"),
+		),
+		// EU: trademark (context-triggered): "Unionsmarke Nr. 018123456"
+		NewRegexScanner(
+			regexp.MustCompile(`(?i:\bEUTM|\bEU[ \t]+trade[ \t]?mark|\bUnionsmarke|\bGemeinschaftsmarke|\bEUIPO|\bmarque[ \t]+de[ \t]+l['’]Union)(?:[ \t]+(?:No\.?|Nr\.?|number|n°))?[:\s]+(0\d{8})\b`),
+			"LEGAL_REFERENCE", 0.95,
+			WithExtractGroup(1),
+			WithSubtype("trademark"),
+		),
+		// EP: European patent: EP 1 234 567 B1, EP3123456A1
+		NewRegexScanner(
+			regexp.MustCompile(`\bEP[ \t]?\d(?:[ \t]?\d{3}){2}(?:[ \t]?[AB]\d)?\b`),
+			"LEGAL_REFERENCE", 0.90,
+			WithSubtype("patent"),
+		),
+		// DE: patent or utility model application: DE 10 2019 123 456.7
+		NewRegexScanner(
+			regexp.MustCompile(`\bDE[ \t]?(?:10|20)[ \t]?\d{4}[ \t]?\d{3}[ \t]?\d{3}(?:\.\d)?\b`),
+			"LEGAL_REFERENCE", 0.90,
+			WithSubtype("patent"),
+		),
+	}
+}
+
+// validateCaseYear checks that the year of a standalone case number is
+// two digits or a plausible four-digit year, so that fractions such as
+// "3 S 1/1000" are not reported.
+func validateCaseYear(s string) bool {
+	_, year, _ := strings.Cut(s[strings.LastIndexAny(s, " \t")+1:], "/")
+	year = strings.TrimRight(year, "abcdefghijklmnopqrstuvwxyz")
+	if len(year) == 2 {
+		return true
+	}
+	y, err := strconv.Atoi(year)
+	return err == nil && y >= 1950 && y <= 2099
+}
+
+// validateECLI checks the year of an ECLI.
+func validateECLI(s string) bool {
+	parts := strings.SplitN(s, ":", 5)
+	y, err := strconv.Atoi(parts[3])
+	return err == nil && y >= 1900 && y <= 2099
+}
+
+// registerCourtNearby reports whether a register court is named within 80
+// bytes before or after a register number.
+func registerCourtNearby(text string, start, end int) bool {
+	from, to := max(0, start-80), min(len(text), end+80)
+	return registerCourtContext.MatchString(text[from:start]) || registerCourtContext.MatchString(text[end:to])
+}
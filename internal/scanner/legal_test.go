@@ -0,0 +1,73 @@
+package scanner
+
+import "testing"
+
+func TestLegalReference_Detected(t *testing.T) {
+	s := DefaultScanner(nil)
+	cases := []struct {
+		name    string
+		input   string
+		want    string
+		subtype string
+	}{
+		{"Aktenzeichen", "Aktenzeichen: 3 O 123/24", "3 O 123/24", "case_number"},
+		{"Az. BGH", "Az.: VI ZR 123/21", "VI ZR 123/21", "case_number"},
+		{"BVerfG standalone", "Beschluss vom 12.05.2020 – 2 BvR 1234/19", "2 BvR 1234/19", "case_number"},
+		{"OGH standalone", "OGH 1 Ob 123/21x", "1 Ob 123/21x", "case_number"},
+		{"ECLI", "Urteil ECLI:DE:BGH:2021:120321UVIZR123.21.0.", "ECLI:DE:BGH:2021:120321UVIZR123.21.0", "ecli"},
+		{"HRB with court", "Amtsgericht Charlottenburg, HRB 12345 B", "HRB 12345 B", "commercial_register"},
+		{"VR with court", "eingetragen im Vereinsregister des AG München unter VR 20411", "VR 20411", "commercial_register"},
+		{"HRA standalone", "Sitz Köln, HRA 5678", "HRA 5678", "commercial_register"},
+		{"Firmenbuch", "Firmenbuchgericht Wien, FN 123456a", "FN 123456a", "firmenbuch"},
+		{"Grundbuch", "eingetragen im Grundbuch von Mitte Blatt 4711", "4711", "land_register"},
+		{"Flurstück", "Flur 3, Flurstück 123/4", "123/4", "land_register"},
+		{"AT Einlagezahl", "EZ 1234 KG 01004 Innere Stadt", "1234 KG 01004", "land_register"},
+		{"EUTM", "Unionsmarke Nr. 018123456", "018123456", "trademark"},
+		{"EP patent", "granted as EP 1 234 567 B1", "EP 1 234 567 B1", "patent"},
+		{"DE patent", "Anmeldung DE 10 2019 123 456.7", "DE 10 2019 123 456.7", "patent"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			for _, e := range s.Scan(tc.input) {
+				if e.Type == "LEGAL_REFERENCE" && e.Text == tc.want {
+					if e.Subtype != tc.subtype {
+						t.Errorf("Subtype = %q, want %q", e.Subtype, tc.subtype)
+					}
+					return
+				}
+			}
+			t.Fatalf("LEGAL_REFERENCE %q not found in %q, got %v", tc.want, tc.input, s.Scan(tc.input))
+		})
+	}
+}
+
+func TestLegalReference_NotDetected(t *testing.T) {
+	s := DefaultScanner(nil)
+	for _, input := range []string{
+		"Seite 3 S 1/1000 der Anlage",
+		"Mix 2 L 5/24 of water with 3 C 10/20 flour.",
+		"The ratio was 4 S 12/2024",
+		"PR 2024 wird überarbeitet",
+		"ECLI:DE:BGH:1234:XYZ",
+		"Blatt 12 des Protokolls",
+	} {
+		for _, e := range s.Scan(input) {
+			if e.Type == "LEGAL_REFERENCE" {
+				t.Errorf("false positive in %q: %v", input, e)
+			}
+		}
+	}
+}
+
+func TestValidateCaseYear(t *testing.T) {
+	for s, want := range map[string]bool{
+		"3 O 123/24":   true,
+		"1 Ob 123/21x": true,
+		"5 Sa 12/2023": true,
+		"3 S 1/1000":   false,
+	} {
+		if got := validateCaseYear(s); got != want {
+			t.Errorf("validateCaseYear(%q) = %v, want %v", s, got, want)
+		}
+	}
+}
@@ -0,0 +1,192 @@
+package scanner
+
+import (
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// --- LOCATION ---
+
+// location is one gazetteer entry: a city, region or country.
+type location struct {
+	kind    string // "city", "region" or "country"
+	country string // ISO 3166-1 alpha-2 code
+	// ambiguous marks names that are also common words ("Essen", "Nice")
+	// and are only accepted with a preposition or postcode in front.
+	ambiguous bool
+}
+
+// locationGazetteer indexes locations by lookup key (see wordsKey).
+type locationGazetteer struct {
+	byKey map[string]location
+	// countryNames maps ISO codes to the English country name used for generalization.
+	countryNames map[string]string
+	// maxWords is the longest name in words, bounding the lookahead per position.
+	maxWords int
+}
+
+var loadLocations = sync.OnceValue(func() *locationGazetteer {
+	g := &locationGazetteer{
+		byKey:        make(map[string]location),
+		countryNames: make(map[string]string),
+	}
+	for _, line := range readGazetteer("locations.tsv.gz") {
+		fields := strings.Split(line, "\t")
+		if len(fields) != 4 {
+			continue
+		}
+		loc := location{kind: fields[0], country: fields[1], ambiguous: strings.Contains(fields[2], "a")}
+		names := strings.Split(fields[3], "|")
+		if loc.kind == "country" {
+			if _, ok := g.countryNames[loc.country]; !ok {
+				g.countryNames[loc.country] = names[0]
+			}
+		}
+		for _, name := range names {
+			words := splitWords(name)
+			key := wordsKey(words)
+			if key == "" {
+				continue
+			}
+			// The first entry wins so that e.g. the German Freiburg is not
+			// shadowed by a later homonym.
+			if _, ok := g.byKey[key]; !ok {
+				g.byKey[key] = loc
+			}
+			if len(words) > g.maxWords {
+				g.maxWords = len(words)
+			}
+		}
+	}
+	return g
+})
+
+// locationPrepositions are words that introduce a place name ("in Essen",
+// "nach Wien", "à Nice"). Case-insensitive. Contractions with articles that
+// also precede ordinary nouns (zum, vom, beim) are deliberately absent.
+var locationPrepositions = map[string]bool{
+	// EN
+	"in": true, "from": true, "to": true, "near": true, "at": true, "via": true, "into": true,
+	// DE
+	"im": true, "aus": true, "nach": true, "bei": true, "von": true, "nahe": true, "bis": true, "ab": true, "richtung": true,
+	// FR
+	"à": true, "au": true, "en": true, "de": true, "vers": true, "près": true, "depuis": true,
+	// IT/ES/PT
+	"a": true, "da": true, "di": true, "per": true, "desde": true, "hacia": true, "para": true, "em": true,
+	// NL
+	"uit": true, "naar": true, "bij": true, "van": true,
+	// PL/CZ
+	"w": true, "z": true, "do": true, "ve": true,
+	// Nordic
+	"i": true, "från": true, "till": true, "fra": true, "til": true,
+}
+
+// weakPrepositions are prepositions that are also common English words or
+// single letters ("Have a Nice day", "decided to Split"). They do not
+// license names that double as common words.
+var weakPrepositions = map[string]bool{
+	"a": true, "i": true, "w": true, "z": true, "to": true, "at": true, "de": true, "do": true,
+}
+
+// postcodeBeforeRe matches a 4–5 digit postcode directly before a place name.
+var postcodeBeforeRe = regexp.MustCompile(`\b\d{4,5}[ \t]+$`)
+
+// LocationScanner finds city, region and country names using the embedded
+// gazetteer of European places in their local and English names.
+//
+// A name must be capitalized. Names that double as common words ("Essen",
+// "Nice", "Split") additionally require a preposition or postcode in front.
+// Context raises the score, so the TUI threshold can drop bare mentions.
+type LocationScanner struct {
+	g *locationGazetteer
+}
+
+// NewLocationScanner creates a LOCATION scanner backed by the embedded gazetteer.
+func NewLocationScanner() *LocationScanner {
+	return &LocationScanner{g: loadLocations()}
+}
+
+func locationScanners() []Scanner {
+	return []Scanner{NewLocationScanner()}
+}
+
+// Scan finds all gazetteer locations in text.
+func (ls *LocationScanner) Scan(text string) []Entity {
+	words := splitWords(text)
+	var entities []Entity
+	for i := 0; i < len(words); i++ {
+		if !isUpperInitial(words[i].text) {
+			continue
+		}
+		for n := min(ls.g.maxWords, len(words)-i); n >= 1; n-- {
+			run := words[i : i+n]
+			if !wordsAdjacent(text, run) {
+				continue
+			}
+			loc, ok := ls.g.byKey[wordsKey(run)]
+			if !ok {
+				continue
+			}
+			start, end := run[0].start, run[n-1].end
+			if embeddedInToken(text, start, end) {
+				continue
+			}
+			hasContext := locationContext(text, words, i, loc.ambiguous)
+			if loc.ambiguous && !hasContext {
+				continue
+			}
+			score := 0.80
+			switch {
+			case loc.ambiguous:
+				score = 0.75
+			case hasContext:
+				score = 0.90
+			}
+			entities = append(entities, Entity{
+				Start:    start,
+				End:      end,
+				Type:     "LOCATION",
+				Text:     text[start:end],
+				Score:    score,
+				Detector: "gazetteer",
+				Subtype:  loc.kind,
+			})
+			i += n - 1
+			break
+		}
+	}
+	return entities
+}
+
+// locationContext reports whether words[i] is preceded by a place preposition
+// on the same line, or by a postcode. Weak prepositions do not count for
+// ambiguous names.
+func locationContext(text string, words []word, i int, ambiguous bool) bool {
+	from := max(0, words[i].start-12)
+	if postcodeBeforeRe.MatchString(text[from:words[i].start]) {
+		return true
+	}
+	if i == 0 {
+		return false
+	}
+	prev := words[i-1]
+	if strings.Trim(text[prev.end:words[i].start], " \t") != "" {
+		return false
+	}
+	prep := strings.ToLower(prev.text)
+	return locationPrepositions[prep] && !(ambiguous && weakPrepositions[prep])
+}
+
+// GeneralizeLocation maps a city, region or country name to the English name
+// of its country ("München" → "Germany", "Toscana" → "Italy"). It reports
+// false for names not in the gazetteer.
+func GeneralizeLocation(name string) (string, bool) {
+	g := loadLocations()
+	loc, ok := g.byKey[nameKey(name)]
+	if !ok {
+		return "", false
+	}
+	country, ok := g.countryNames[loc.country]
+	return country, ok
+}
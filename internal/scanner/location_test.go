@@ -0,0 +1,102 @@
+package scanner
+
+import "testing"
+
+func TestLocation_TruePositives(t *testing.T) {
+	s := DefaultScanner(nil)
+	cases := []struct {
+		name    string
+		input   string
+		want    string
+		subtype string
+	}{
+		{"DE city", "Sie wohnt seit Jahren in München.", "München", "city"},
+		{"EN exonym", "The meeting is in Munich next week.", "Munich", "city"},
+		{"multi-word city", "Er zog nach Frankfurt am Main.", "Frankfurt am Main", "city"},
+		{"abbreviated multi-word", "Wir sind in St. Gallen.", "St. Gallen", "city"},
+		{"hyphenated region", "Sie lebt in Baden-Württemberg.", "Baden-Württemberg", "region"},
+		{"country", "Er kommt aus Österreich.", "Österreich", "country"},
+		{"FR preposition", "Elle habite à Lyon depuis 2010.", "Lyon", "city"},
+		{"sentence start without context", "Wien ist schön im Frühling.", "Wien", "city"},
+		{"ambiguous with preposition", "Wir fahren morgen nach Essen.", "Essen", "city"},
+		{"ambiguous with postcode", "Bitte senden an 45127 Essen.", "Essen", "city"},
+		{"ambiguous FR", "Nous sommes à Nice.", "Nice", "city"},
+		{"all caps", "LIEFERADRESSE: WIEN", "WIEN", "city"},
+		{"Greek script", "Ζει στην Αθήνα.", "Αθήνα", "city"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			entities := s.Scan(tc.input)
+			for _, e := range entities {
+				if e.Type == "LOCATION" && e.Text == tc.want {
+					if e.Subtype != tc.subtype {
+						t.Errorf("subtype = %q, want %q", e.Subtype, tc.subtype)
+					}
+					return
+				}
+			}
+			t.Errorf("LOCATION not found in %q: wanted %q, got %v", tc.input, tc.want, entities)
+		})
+	}
+}
+
+func TestLocation_TrueNegatives(t *testing.T) {
+	s := DefaultScanner(nil)
+	cases := []struct {
+		name  string
+		input string
+	}{
+		{"ambiguous common noun", "Essen ist fertig, kommt zu Tisch."},
+		{"ambiguous after article", "Das Essen war gut."},
+		{"ambiguous contraction", "Wir treffen uns zum Essen."},
+		{"ambiguous EN adjective", "Nice to meet you."},
+		{"ambiguous after article a", "Have a Nice day."},
+		{"ambiguous after infinitive to", "They decided to Split the bill."},
+		{"lowercase", "we will split the bill in berlin"},
+		{"derived adjective", "Die Frankfurter Allgemeine berichtet."},
+		{"email local part", "mail berlin@example.com"},
+		{"domain", "siehe Berlin.de für Details"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			for _, e := range s.Scan(tc.input) {
+				if e.Type == "LOCATION" {
+					t.Errorf("LOCATION false positive in %q: got %v", tc.input, e)
+				}
+			}
+		})
+	}
+}
+
+func TestLocation_ContextRaisesScore(t *testing.T) {
+	s := NewLocationScanner()
+	bare := s.Scan("Berlin ist groß.")
+	ctx := s.Scan("Ich wohne in Berlin.")
+	if len(bare) != 1 || len(ctx) != 1 {
+		t.Fatalf("want one entity each, got %v and %v", bare, ctx)
+	}
+	if ctx[0].Score <= bare[0].Score {
+		t.Errorf("context score %.2f should exceed bare score %.2f", ctx[0].Score, bare[0].Score)
+	}
+}
+
+func TestGeneralizeLocation(t *testing.T) {
+	cases := []struct {
+		name string
+		want string
+		ok   bool
+	}{
+		{"München", "Germany", true},
+		{"Munich", "Germany", true},
+		{"st. gallen", "Switzerland", true},
+		{"Toscana", "Italy", true},
+		{"Österreich", "Austria", true},
+		{"Atlantis", "", false},
+	}
+	for _, tc := range cases {
+		got, ok := GeneralizeLocation(tc.name)
+		if got != tc.want || ok != tc.ok {
+			t.Errorf("GeneralizeLocation(%q) = %q, %v; want %q, %v", tc.name, got, ok, tc.want, tc.ok)
+		}
+	}
+}
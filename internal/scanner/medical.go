@@ -0,0 +1,159 @@
+package scanner
+
+import (
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// --- MEDICAL: medications ---
+
+// medicationGazetteer indexes drug names by lookup key (see wordsKey).
+type medicationGazetteer struct {
+	names map[string]bool
+	// maxWords is the longest name in words, bounding the lookahead per position.
+	maxWords int
+}
+
+var loadMedications = sync.OnceValue(func() *medicationGazetteer {
+	g := &medicationGazetteer{names: make(map[string]bool)}
+	for _, line := range readGazetteer("medications.tsv.gz") {
+		for _, name := range strings.Split(line, "|") {
+			words := splitWords(name)
+			if key := wordsKey(words); key != "" {
+				g.names[key] = true
+				g.maxWords = max(g.maxWords, len(words))
+			}
+		}
+	}
+	return g
+})
+
+// dosageRe matches a strength and optional schedule directly after a drug
+// name: " 47,5 mg", " 500 mg/d", " 20 mg 1-0-1", " 10 IE 2x täglich".
+var dosageRe = regexp.MustCompile(`^[ \t]+\d+(?:[.,]\d+)?[ \t]?(?:mg|µg|mcg|g|ml|mL|IE|I\.E\.|IU|mmol|%)(?:/(?:d|Tag|day|h|kg|ml|mL))?\b` +
+	`(?:[ \t]+\d(?:[.,]5)?(?:-\d(?:[.,]5)?){2,3}|[ \t]+\d[ \t]?x[ \t]?(?:täglich|tgl\.|daily|am Tag|pro Tag|per day))?`)
+
+// MedicationScanner finds drug names from the embedded medication lexicon
+// (active substances and common brand names). A directly following dosage
+// ("Metoprolol 47,5 mg 1-0-1") is included in the entity and raises the score.
+type MedicationScanner struct {
+	g *medicationGazetteer
+}
+
+// NewMedicationScanner creates a medication scanner backed by the embedded lexicon.
+func NewMedicationScanner() *MedicationScanner {
+	return &MedicationScanner{g: loadMedications()}
+}
+
+// Scan finds all lexicon medications in text.
+func (ms *MedicationScanner) Scan(text string) []Entity {
+	words := splitWords(text)
+	var entities []Entity
+	for i := 0; i < len(words); i++ {
+		for n := min(ms.g.maxWords, len(words)-i); n >= 1; n-- {
+			run := words[i : i+n]
+			if !wordsAdjacent(text, run) || !ms.g.names[wordsKey(run)] {
+				continue
+			}
+			start, end := run[0].start, run[n-1].end
+			if embeddedInToken(text, start, end) {
+				continue
+			}
+			score := 0.75
+			if loc := dosageRe.FindStringIndex(text[end:]); loc != nil {
+				end += loc[1]
+				score = 0.90
+			}
+			entities = append(entities, Entity{
+				Start:    start,
+				End:      end,
+				Type:     "MEDICAL",
+				Text:     text[start:end],
+				Score:    score,
+				Detector: "gazetteer",
+				Subtype:  "medication",
+			})
+			i += n - 1
+			break
+		}
+	}
+	return entities
+}
+
+// --- MEDICAL: code and identifier validators ---
+
+// validateATC checks that an ATC code starts with one of the 14 anatomical
+// main groups.
+func validateATC(s string) bool {
+	return s != "" && strings.ContainsRune("ABCDGHJLMNPRSV", rune(s[0]))
+}
+
+// validateOPS checks that an OPS code starts with a chapter in use:
+// 1 diagnostics, 3 imaging, 5 operations, 6 medications, 8 non-operative
+// therapy, 9 supplementary measures.
+func validateOPS(s string) bool {
+	return s != "" && strings.ContainsRune("135689", rune(s[0]))
+}
+
+// validateCPT checks a CPT code: Category I codes are 00100–99607,
+// Category II and III codes are four digits followed by F or T.
+func validateCPT(s string) bool {
+	if len(s) != 5 {
+		return false
+	}
+	if s[4] == 'F' || s[4] == 'T' {
+		return true
+	}
+	return s >= "00100" && s <= "99607"
+}
+
+// validateKVNR checks the check digit of a German Krankenversichertennummer
+// (letter + 8 digits + check digit). The letter counts as its two-digit
+// position in the alphabet (A = 01); the resulting ten digits are weighted
+// 1, 2, 1, 2, …, products above 9 reduced to their digit sum, and the sum
+// taken modulo 10.
+func validateKVNR(s string) bool {
+	if len(s) != 10 || s[0] < 'A' || s[0] > 'Z' {
+		return false
+	}
+	pos := int(s[0]-'A') + 1
+	digits := []int{pos / 10, pos % 10}
+	for _, r := range s[1:9] {
+		if r < '0' || r > '9' {
+			return false
+		}
+		digits = append(digits, int(r-'0'))
+	}
+	sum := 0
+	for i, d := range digits {
+		p := d * (i%2 + 1)
+		sum += p/10 + p%10
+	}
+	return s[9] >= '0' && s[9] <= '9' && sum%10 == int(s[9]-'0')
+}
+
+// validateNHS checks the modulus 11 check digit of an NHS number: the first
+// nine digits are weighted 10 down to 2, and 11 minus the remainder is the
+// check digit (11 becomes 0; 10 is never issued).
+func validateNHS(s string) bool {
+	digits := asciiDigits(s)
+	if len(digits) != 10 {
+		return false
+	}
+	sum := 0
+	for i := 0; i < 9; i++ {
+		sum += int(digits[i]-'0') * (10 - i)
+	}
+	check := 11 - sum%11
+	if check == 11 {
+		check = 0
+	}
+	return check != 10 && check == int(digits[9]-'0')
+}
+
+// validateNPI checks a US National Provider Identifier: ten digits starting
+// with 1 or 2 whose Luhn check includes the card issuer prefix 80840.
+func validateNPI(s string) bool {
+	return len(s) == 10 && (s[0] == '1' || s[0] == '2') && validateLuhn("80840"+s)
+}
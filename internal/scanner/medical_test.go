@@ -0,0 +1,87 @@
+package scanner
+
+import "testing"
+
+func TestMedical_Expansion(t *testing.T) {
+	s := DefaultScanner(nil)
+	cases := []struct {
+		name    string
+		input   string
+		want    string
+		subtype string
+		score   float64
+	}{
+		{"drug with dosage", "Medikation: Metoprolol 47,5 mg 1-0-1", "Metoprolol 47,5 mg 1-0-1", "medication", 0.90},
+		{"drug alone", "She takes ibuprofen when needed.", "ibuprofen", "medication", 0.75},
+		{"brand name", "Seit März Xarelto 20 mg/d", "Xarelto 20 mg/d", "medication", 0.90},
+		{"multi-word substance", "valproic acid 500 mg", "valproic acid 500 mg", "medication", 0.90},
+		{"ATC with context", "ATC-Code: C07AB02", "C07AB02", "atc", 0.90},
+		{"ATC standalone", "Wirkstoffgruppe C07AB02 laut Fachinfo", "C07AB02", "atc", 0.75},
+		{"OPS", "OPS: 5-470.11", "5-470.11", "ops", 0.90},
+		{"OPS with letter", "OPS-Kode 8-98f.10", "8-98f.10", "ops", 0.90},
+		{"CPT", "CPT 99213", "99213", "cpt", 0.90},
+		{"CPT category II", "CPT code: 3008F", "3008F", "cpt", 0.90},
+		{"KVNR with context", "Versichertennummer: A123456780", "A123456780", "kvnr", 0.95},
+		{"NHS number", "NHS Number: 943 476 5919", "943 476 5919", "nhs", 0.95},
+		{"NPI", "NPI: 1234567893", "1234567893", "npi", 0.95},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			for _, e := range s.Scan(tc.input) {
+				if e.Type == "MEDICAL" && e.Text == tc.want {
+					if e.Subtype != tc.subtype {
+						t.Errorf("Subtype = %q, want %q", e.Subtype, tc.subtype)
+					}
+					if e.Score != tc.score {
+						t.Errorf("Score = %.2f, want %.2f", e.Score, tc.score)
+					}
+					return
+				}
+			}
+			t.Fatalf("MEDICAL %q not found in %q, got %v", tc.want, tc.input, s.Scan(tc.input))
+		})
+	}
+}
+
+func TestMedical_KVNRNeedsTrigger(t *testing.T) {
+	// A123456780 has a valid check digit; without a keyword it is just as
+	// likely a reference code.
+	s := DefaultScanner(nil)
+	for _, input := range []string{"Karte A123456780 eingelesen", "Order reference A123456780"} {
+		if n := countEntitiesOfType(s.Scan(input), "MEDICAL"); n != 0 {
+			t.Errorf("reference code flagged as MEDICAL in %q", input)
+		}
+	}
+}
+
+func TestMedical_ChecksumsRejected(t *testing.T) {
+	s := DefaultScanner(nil)
+	cases := []string{
+		"Versichertennummer: A123456781",
+		"Karte A123456781 eingelesen",
+		"NHS Number: 943 476 5918",
+		"NPI: 1234567890",
+		"ATC-Code: E07AB02",
+		"OPS: 4-470.11",
+		"CPT 00042",
+	}
+	for _, input := range cases {
+		if n := countEntitiesOfType(s.Scan(input), "MEDICAL"); n != 0 {
+			t.Errorf("invalid code flagged as MEDICAL in %q", input)
+		}
+	}
+}
+
+func TestValidateKVNR(t *testing.T) {
+	for s, want := range map[string]bool{
+		"A123456780": true,
+		"A123456781": false,
+		"Z000000001": false,
+		"a123456780": false,
+		"A12345678":  false,
+	} {
+		if got := validateKVNR(s); got != want {
+			t.Errorf("validateKVNR(%q) = %v, want %v", s, got, want)
+		}
+	}
+}
@@ -0,0 +1,269 @@
+package scanner
+
+import "strings"
+
+// --- MRZ (machine-readable zone, ICAO 9303) ---
+
+// MRZ line lengths per document format.
+const (
+	mrzTD1Len = 30 // ID cards: 3 lines × 30
+	mrzTD2Len = 36 // older ID cards, visas: 2 lines × 36
+	mrzTD3Len = 44 // passports: 2 lines × 44
+)
+
+// MRZScanner finds 2- and 3-line MRZ blocks as produced by OCR of passports
+// and ID cards. A block is only reported when its ICAO 9303 check digits
+// verify, so random uppercase lines do not match.
+//
+// By default the whole block is emitted as one ID_NUMBER entity. In
+// decomposed mode the embedded holder name (PERSON), birth date (DATE) and
+// document number (ID_NUMBER) are emitted as separate entities instead.
+type MRZScanner struct {
+	decompose bool
+}
+
+// NewMRZScanner creates an MRZ scanner. If decompose is true, sub-entities
+// are emitted instead of the whole block.
+func NewMRZScanner(decompose bool) *MRZScanner {
+	return &MRZScanner{decompose: decompose}
+}
+
+func mrzScanners() []Scanner {
+	return []Scanner{NewMRZScanner(false)}
+}
+
+// mrzLine is one candidate MRZ line with its byte offsets in the full text.
+type mrzLine struct {
+	text  string
+	start int
+	end   int
+}
+
+// mrzField describes a sub-field within an MRZ line by line index and column range.
+type mrzField struct {
+	line, from, to int
+}
+
+// mrzLayout describes where check-digit protected fields live for one format.
+type mrzLayout struct {
+	lines     int
+	length    int
+	docNumber mrzField
+	docCheck  mrzField
+	birth     mrzField
+	birthChk  mrzField
+	expiry    mrzField
+	expiryChk mrzField
+	name      mrzField
+	// composite lists the ranges covered by the final composite check digit.
+	composite      []mrzField
+	compositeCheck mrzField
+}
+
+var mrzLayouts = []mrzLayout{
+	{
+		lines: 2, length: mrzTD3Len,
+		docNumber: mrzField{1, 0, 9}, docCheck: mrzField{1, 9, 10},
+		birth: mrzField{1, 13, 19}, birthChk: mrzField{1, 19, 20},
+		expiry: mrzField{1, 21, 27}, expiryChk: mrzField{1, 27, 28},
+		name:           mrzField{0, 5, 44},
+		composite:      []mrzField{{1, 0, 10}, {1, 13, 20}, {1, 21, 43}},
+		compositeCheck: mrzField{1, 43, 44},
+	},
+	{
+		lines: 2, length: mrzTD2Len,
+		docNumber: mrzField{1, 0, 9}, docCheck: mrzField{1, 9, 10},
+		birth: mrzField{1, 13, 19}, birthChk: mrzField{1, 19, 20},
+		expiry: mrzField{1, 21, 27}, expiryChk: mrzField{1, 27, 28},
+		name:           mrzField{0, 5, 36},
+		composite:      []mrzField{{1, 0, 10}, {1, 13, 20}, {1, 21, 35}},
+		compositeCheck: mrzField{1, 35, 36},
+	},
+	{
+		lines: 3, length: mrzTD1Len,
+		docNumber: mrzField{0, 5, 14}, docCheck: mrzField{0, 14, 15},
+		birth: mrzField{1, 0, 6}, birthChk: mrzField{1, 6, 7},
+		expiry: mrzField{1, 8, 14}, expiryChk: mrzField{1, 14, 15},
+		name:           mrzField{2, 0, 30},
+		composite:      []mrzField{{0, 5, 30}, {1, 0, 7}, {1, 8, 15}, {1, 18, 29}},
+		compositeCheck: mrzField{1, 29, 30},
+	},
+}
+
+// Scan finds all valid MRZ blocks in text.
+func (ms *MRZScanner) Scan(text string) []Entity {
+	lines := mrzCandidateLines(text)
+	var entities []Entity
+	for i := 0; i < len(lines); {
+		matched := false
+		for _, layout := range mrzLayouts {
+			if i+layout.lines > len(lines) {
+				continue
+			}
+			block := lines[i : i+layout.lines]
+			if !mrzBlockFits(text, block, layout.length) || !layout.verify(block) {
+				continue
+			}
+			entities = append(entities, ms.emit(text, block, layout)...)
+			i += layout.lines
+			matched = true
+			break
+		}
+		if !matched {
+			i++
+		}
+	}
+	return entities
+}
+
+// mrzCandidateLines splits text into lines and returns those that consist
+// solely of MRZ characters (A–Z, 0–9, '<') after trimming surrounding blanks.
+// Consecutive candidates must also be adjacent lines in the source, which is
+// checked separately by mrzBlockFits.
+func mrzCandidateLines(text string) []mrzLine {
+	var out []mrzLine
+	pos := 0
+	for pos <= len(text) {
+		nl := strings.IndexByte(text[pos:], '\n')
+		lineEnd := len(text)
+		if nl >= 0 {
+			lineEnd = pos + nl
+		}
+		start, end := pos, lineEnd
+		for start < end && (text[start] == ' ' || text[start] == '\t') {
+			start++
+		}
+		for end > start && (text[end-1] == ' ' || text[end-1] == '\t' || text[end-1] == '\r') {
+			end--
+		}
+		if n := end - start; (n == mrzTD1Len || n == mrzTD2Len || n == mrzTD3Len) && isMRZLine(text[start:end]) {
+			out = append(out, mrzLine{text: text[start:end], start: start, end: end})
+		}
+		if nl < 0 {
+			break
+		}
+		pos = lineEnd + 1
+	}
+	return out
+}
+
+func isMRZLine(s string) bool {
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if !(c >= 'A' && c <= 'Z') && !(c >= '0' && c <= '9') && c != '<' {
+			return false
+		}
+	}
+	// Every MRZ line contains filler; this rejects long uppercase words and digit runs.
+	return strings.IndexByte(s, '<') >= 0
+}
+
+// mrzBlockFits reports whether all lines have the expected length and follow
+// each other directly (only a line break between them).
+func mrzBlockFits(text string, block []mrzLine, length int) bool {
+	for i, l := range block {
+		if len(l.text) != length {
+			return false
+		}
+		if i == 0 {
+			continue
+		}
+		sep := text[block[i-1].end:l.start]
+		if strings.TrimSpace(sep) != "" || strings.Count(sep, "\n") != 1 {
+			return false
+		}
+	}
+	return true
+}
+
+func (f mrzField) of(block []mrzLine) string {
+	return block[f.line].text[f.from:f.to]
+}
+
+// verify checks the document number, birth date, expiry and composite check digits.
+func (l mrzLayout) verify(block []mrzLine) bool {
+	if !mrzCheck(l.docNumber.of(block), l.docCheck.of(block)) ||
+		!mrzCheck(l.birth.of(block), l.birthChk.of(block)) ||
+		!mrzCheck(l.expiry.of(block), l.expiryChk.of(block)) {
+		return false
+	}
+	var composite strings.Builder
+	for _, f := range l.composite {
+		composite.WriteString(f.of(block))
+	}
+	return mrzCheck(composite.String(), l.compositeCheck.of(block))
+}
+
+// mrzCheck reports whether check is the ICAO 9303 check digit of field.
+func mrzCheck(field, check string) bool {
+	if len(check) != 1 || check[0] < '0' || check[0] > '9' {
+		return false
+	}
+	return int(check[0]-'0') == mrzCheckDigit(field)
+}
+
+// mrzCheckDigit computes the ICAO 9303 check digit: character values
+// (0–9, A=10 … Z=35, '<'=0) weighted 7, 3, 1 repeating, modulo 10.
+func mrzCheckDigit(s string) int {
+	weights := [3]int{7, 3, 1}
+	sum := 0
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		v := 0
+		switch {
+		case c >= '0' && c <= '9':
+			v = int(c - '0')
+		case c >= 'A' && c <= 'Z':
+			v = int(c-'A') + 10
+		}
+		sum += v * weights[i%3]
+	}
+	return sum % 10
+}
+
+func (ms *MRZScanner) emit(text string, block []mrzLine, l mrzLayout) []Entity {
+	if !ms.decompose {
+		start, end := block[0].start, block[len(block)-1].end
+		return []Entity{{
+			Start:    start,
+			End:      end,
+			Type:     "ID_NUMBER",
+			Text:     text[start:end],
+			Score:    0.99,
+			Detector: "mrz",
+		}}
+	}
+
+	var out []Entity
+	add := func(f mrzField, entityType string, score float64) {
+		line := block[f.line]
+		from, to := f.from, f.to
+		// Trim filler so the entity covers only the meaningful characters.
+		for to > from && line.text[to-1] == '<' {
+			to--
+		}
+		if to == from {
+			return
+		}
+		out = append(out, Entity{
+			Start:    line.start + from,
+			End:      line.start + to,
+			Type:     entityType,
+			Text:     line.text[from:to],
+			Score:    score,
+			Detector: "mrz",
+		})
+	}
+
+	// Entities are emitted in reading order; the name precedes the numbers
+	// on TD2/TD3 but follows them on TD1.
+	if l.name.line == 0 {
+		add(l.name, "PERSON", 0.95)
+	}
+	add(l.docNumber, "ID_NUMBER", 0.99)
+	add(l.birth, "DATE", 0.95)
+	if l.name.line != 0 {
+		add(l.name, "PERSON", 0.95)
+	}
+	return out
+}
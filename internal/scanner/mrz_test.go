@@ -0,0 +1,123 @@
+package scanner
+
+import (
+	"strings"
+	"testing"
+)
+
+// ICAO 9303 specimen MRZs (check digits valid).
+const (
+	mrzTD3Line1 = "P<UTOERIKSSON<<ANNA<MARIA<<<<<<<<<<<<<<<<<<<"
+	mrzTD3Line2 = "L898902C36UTO7408122F1204159ZE184226B<<<<<10"
+	mrzTD2Line1 = "I<UTOERIKSSON<<ANNA<MARIA<<<<<<<<<<<"
+	mrzTD2Line2 = "D231458907UTO7408122F1204159<<<<<<<6"
+	mrzTD1Line1 = "I<UTOD231458907<<<<<<<<<<<<<<<"
+	mrzTD1Line2 = "7408122F1204159UTO<<<<<<<<<<<6"
+	mrzTD1Line3 = "ERIKSSON<<ANNA<MARIA<<<<<<<<<<"
+)
+
+func TestMRZ_TruePositives(t *testing.T) {
+	s := DefaultScanner(nil)
+	cases := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"TD3 passport", "Reisepass:\n" + mrzTD3Line1 + "\n" + mrzTD3Line2 + "\nEnde",
+			mrzTD3Line1 + "\n" + mrzTD3Line2},
+		{"TD3 German passport",
+			"P<D<<MUSTERMANN<<ERIKA<<<<<<<<<<<<<<<<<<<<<<\nC01X00T478D<<8308126F3101311<<<<<<<<<<<<<<04",
+			"P<D<<MUSTERMANN<<ERIKA<<<<<<<<<<<<<<<<<<<<<<\nC01X00T478D<<8308126F3101311<<<<<<<<<<<<<<04"},
+		{"TD2 ID card", mrzTD2Line1 + "\n" + mrzTD2Line2, mrzTD2Line1 + "\n" + mrzTD2Line2},
+		{"TD1 ID card", "OCR:\n" + mrzTD1Line1 + "\n" + mrzTD1Line2 + "\n" + mrzTD1Line3 + "\n",
+			mrzTD1Line1 + "\n" + mrzTD1Line2 + "\n" + mrzTD1Line3},
+		{"CRLF and indentation", "  " + mrzTD3Line1 + "\r\n  " + mrzTD3Line2 + "  \r\n",
+			mrzTD3Line1 + "\r\n  " + mrzTD3Line2},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			entities := s.Scan(tc.input)
+			if !hasEntityWithText(entities, "ID_NUMBER", tc.want) {
+				t.Errorf("MRZ block not found in %q, got %v", tc.input, entities)
+			}
+		})
+	}
+}
+
+func TestMRZ_TrueNegatives(t *testing.T) {
+	s := NewMRZScanner(false)
+	cases := []struct {
+		name  string
+		input string
+	}{
+		{"wrong document check digit", mrzTD3Line1 + "\n" + strings.Replace(mrzTD3Line2, "L898902C36", "L898902C37", 1)},
+		{"wrong composite check digit", mrzTD3Line1 + "\n" + mrzTD3Line2[:43] + "1"},
+		{"single line", mrzTD3Line2},
+		{"lines not adjacent", mrzTD3Line1 + "\n\n" + mrzTD3Line2},
+		{"mixed lengths", mrzTD2Line1 + "\n" + mrzTD3Line2},
+		{"TD1 missing name line", mrzTD1Line1 + "\n" + mrzTD1Line2},
+		{"lowercase", strings.ToLower(mrzTD3Line1) + "\n" + strings.ToLower(mrzTD3Line2)},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if entities := s.Scan(tc.input); len(entities) > 0 {
+				t.Errorf("unexpected MRZ match in %q: %v", tc.input, entities)
+			}
+		})
+	}
+}
+
+func TestMRZ_Decomposed(t *testing.T) {
+	s := NewMRZScanner(true)
+
+	cases := []struct {
+		name  string
+		input string
+		want  []Entity
+	}{
+		{"TD3", mrzTD3Line1 + "\n" + mrzTD3Line2, []Entity{
+			{Type: "PERSON", Text: "ERIKSSON<<ANNA<MARIA"},
+			{Type: "ID_NUMBER", Text: "L898902C3"},
+			{Type: "DATE", Text: "740812"},
+		}},
+		{"TD1", mrzTD1Line1 + "\n" + mrzTD1Line2 + "\n" + mrzTD1Line3, []Entity{
+			{Type: "ID_NUMBER", Text: "D23145890"},
+			{Type: "DATE", Text: "740812"},
+			{Type: "PERSON", Text: "ERIKSSON<<ANNA<MARIA"},
+		}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			entities := s.Scan(tc.input)
+			if len(entities) != len(tc.want) {
+				t.Fatalf("got %d entities, want %d: %v", len(entities), len(tc.want), entities)
+			}
+			for i, w := range tc.want {
+				e := entities[i]
+				if e.Type != w.Type || e.Text != w.Text {
+					t.Errorf("entity %d = %s %q, want %s %q", i, e.Type, e.Text, w.Type, w.Text)
+				}
+				if tc.input[e.Start:e.End] != e.Text {
+					t.Errorf("entity %d offsets [%d:%d] = %q, want %q", i, e.Start, e.End, tc.input[e.Start:e.End], e.Text)
+				}
+			}
+		})
+	}
+}
+
+func TestMRZCheckDigit(t *testing.T) {
+	cases := []struct {
+		field string
+		want  int
+	}{
+		{"L898902C3", 6},
+		{"740812", 2},
+		{"120415", 9},
+		{"<<<<<<<<<<<<<<", 0},
+	}
+	for _, tc := range cases {
+		if got := mrzCheckDigit(tc.field); got != tc.want {
+			t.Errorf("mrzCheckDigit(%q) = %d, want %d", tc.field, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,300 @@
+package scanner
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+)
+
+// --- PERSON (gazetteer) ---
+
+// nameEntry is one first-name or surname gazetteer entry.
+type nameEntry struct {
+	// freq is the frequency class: 1 = very common, 2 = common, 3 = less common.
+	freq int
+	// ambiguous marks names that are also common words ("Will", "Rose",
+	// "Koch", "Bauer"). Ambiguous first names need a known surname;
+	// ambiguous surnames only score lower.
+	ambiguous bool
+}
+
+// nameGazetteer holds the embedded person-name lists, keyed by lowercased name.
+type nameGazetteer struct {
+	first map[string]nameEntry
+	last  map[string]nameEntry
+	// stop lists capitalized words that never form part of a name
+	// (German nouns, calendar words, organisational words).
+	stop map[string]bool
+}
+
+var loadNames = sync.OnceValue(func() *nameGazetteer {
+	return &nameGazetteer{
+		first: readNameList("first_names.tsv.gz"),
+		last:  readNameList("surnames.tsv.gz"),
+		stop:  readStopList("name_stoplist.tsv.gz"),
+	}
+})
+
+// readNameList parses a name<TAB>freq<TAB>flags gazetteer.
+func readNameList(file string) map[string]nameEntry {
+	names := make(map[string]nameEntry)
+	for _, line := range readGazetteer(file) {
+		fields := strings.Split(line, "\t")
+		if len(fields) < 2 {
+			continue
+		}
+		freq, err := strconv.Atoi(fields[1])
+		if err != nil {
+			continue
+		}
+		e := nameEntry{freq: freq}
+		if len(fields) > 2 {
+			e.ambiguous = strings.Contains(fields[2], "a")
+		}
+		names[strings.ToLower(fields[0])] = e
+	}
+	return names
+}
+
+func readStopList(file string) map[string]bool {
+	stop := make(map[string]bool)
+	for _, line := range readGazetteer(file) {
+		stop[strings.ToLower(line)] = true
+	}
+	return stop
+}
+
+// surnameParticles may sit between the given name and the surname
+// ("Ludwig van Beethoven", "Anna de Vries"). They must be lowercase.
+var surnameParticles = map[string]bool{
+	"van": true, "von": true, "der": true, "den": true, "de": true, "di": true,
+	"da": true, "del": true, "della": true, "dos": true, "das": true, "du": true,
+	"le": true, "la": true, "ten": true, "ter": true, "zu": true,
+}
+
+// maxNameWords bounds the words following a given name: middle names,
+// initials, particles and surnames.
+const maxNameWords = 4
+
+// NameScanner finds person names without a trigger word, using embedded
+// first-name and surname gazetteers for the supported languages.
+//
+// A match starts with a known first name and ends in a known surname
+// ("Thomas Schmidt", "Anna Maria de Vries"), or is written surname first
+// ("Schmidt, Thomas"). A common first name followed by an unknown
+// capitalized word is accepted at a lower score. Every word must be
+// capitalized and not on the stop-list, which removes most collisions with
+// capitalized German nouns. First names that double as ordinary words
+// ("Will", "Mark", "Rose") are only accepted with a known surname.
+//
+// Scores grow with the gazetteer frequency of both parts, so the TUI
+// threshold can trade recall against precision.
+type NameScanner struct {
+	g *nameGazetteer
+}
+
+// NewNameScanner creates a PERSON scanner backed by the embedded name gazetteers.
+func NewNameScanner() *NameScanner {
+	return &NameScanner{g: loadNames()}
+}
+
+func nameScanners() []Scanner {
+	return []Scanner{NewNameScanner()}
+}
+
+// Scan finds all gazetteer person names in text.
+func (ns *NameScanner) Scan(text string) []Entity {
+	words := splitWords(text)
+	var entities []Entity
+	for i := 0; i < len(words); i++ {
+		last, score, ok := ns.matchForward(text, words, i)
+		if !ok {
+			last, score, ok = ns.matchInverted(text, words, i)
+		}
+		if !ok {
+			continue
+		}
+		start, end := words[i].start, words[last].end
+		if embeddedInToken(text, start, end) {
+			continue
+		}
+		entities = append(entities, Entity{
+			Start:    start,
+			End:      end,
+			Type:     "PERSON",
+			Text:     text[start:end],
+			Score:    score,
+			Detector: "gazetteer",
+		})
+		i = last
+	}
+	return entities
+}
+
+// matchForward matches "First [Middle|Initial|particle]* Surname" starting
+// at words[i] and returns the index of the last word of the name.
+func (ns *NameScanner) matchForward(text string, words []word, i int) (int, float64, bool) {
+	first, ok := ns.lookup(ns.g.first, words[i].text)
+	if !ok {
+		return 0, 0, false
+	}
+
+	best, unknown := -1, -1
+	var surname nameEntry
+	for k := i + 1; k < len(words) && k <= i+maxNameWords; k++ {
+		if !nameGap(text, words[k-1], words[k]) {
+			break
+		}
+		w := words[k].text
+		if surnameParticles[w] || isInitial(text, words[k]) {
+			continue
+		}
+		if !isNameShaped(w) || ns.g.stop[strings.ToLower(w)] {
+			break
+		}
+		if e, ok := ns.g.last[strings.ToLower(w)]; ok {
+			// Keep extending: Spanish and Portuguese names carry two surnames.
+			best, surname = k, e
+			continue
+		}
+		if _, ok := ns.g.first[strings.ToLower(w)]; ok {
+			continue // middle name
+		}
+		if best < 0 && k == i+1 {
+			unknown = k
+		}
+		break
+	}
+
+	switch {
+	case best >= 0:
+		if first.ambiguous && surname.ambiguous && sentenceInitial(text, words[i].start) {
+			// "Will Bauer ..." at sentence start is as likely a phrase as a name.
+			return 0, 0, false
+		}
+		return best, nameScore(first, &surname), true
+	case unknown >= 0 && !first.ambiguous && first.freq == 1:
+		score := nameScore(first, nil)
+		if sentenceInitial(text, words[i].start) {
+			// Capitalization of the first word carries no signal here.
+			score = math.Round((score-0.05)*100) / 100
+		}
+		return unknown, score, true
+	}
+	return 0, 0, false
+}
+
+// matchInverted matches "Surname, First" as used in lists and forms.
+func (ns *NameScanner) matchInverted(text string, words []word, i int) (int, float64, bool) {
+	surname, ok := ns.lookup(ns.g.last, words[i].text)
+	if !ok || surname.ambiguous || i+1 >= len(words) {
+		return 0, 0, false
+	}
+	sep := text[words[i].end:words[i+1].start]
+	if !strings.HasPrefix(sep, ",") || strings.Trim(sep[1:], " \t") != "" || len(sep) < 2 {
+		return 0, 0, false
+	}
+	first, ok := ns.lookup(ns.g.first, words[i+1].text)
+	if !ok || first.ambiguous {
+		return 0, 0, false
+	}
+	return i + 1, nameScore(first, &surname), true
+}
+
+// lookup returns the gazetteer entry for a capitalized, non-stop-listed word.
+func (ns *NameScanner) lookup(list map[string]nameEntry, w string) (nameEntry, bool) {
+	if !isNameShaped(w) {
+		return nameEntry{}, false
+	}
+	key := strings.ToLower(w)
+	if ns.g.stop[key] {
+		return nameEntry{}, false
+	}
+	e, ok := list[key]
+	return e, ok
+}
+
+// nameScore derives a confidence from the frequency classes of the first
+// name and surname. A nil surname means the surname is not in the gazetteer,
+// which scores below any known pair.
+func nameScore(first nameEntry, surname *nameEntry) float64 {
+	score := 0.55 + 0.15*freqWeight(first)
+	if surname != nil {
+		score += 0.15 * freqWeight(*surname)
+	} else {
+		score -= 0.05
+	}
+	return math.Round(score*100) / 100
+}
+
+func freqWeight(e nameEntry) float64 {
+	var w float64
+	switch e.freq {
+	case 1:
+		w = 1.0
+	case 2:
+		w = 0.7
+	default:
+		w = 0.4
+	}
+	if e.ambiguous {
+		w /= 2
+	}
+	return w
+}
+
+// isNameShaped reports whether w is written like a name: an uppercase
+// initial followed by lowercase letters, allowing inner capitals only after
+// a hyphen or apostrophe or a leading Mc/Mac (Müller-Lüdenscheidt, O'Brien,
+// McDonald). All-caps and camel-case words ("PayPal") are rejected.
+func isNameShaped(w string) bool {
+	if !isUpperInitial(w) {
+		return false
+	}
+	lower := 0
+	var prev rune
+	for i, r := range w {
+		switch {
+		case i == 0:
+		case unicode.IsLower(r):
+			lower++
+		case unicode.IsUpper(r):
+			if prev != '-' && prev != '\'' && prev != '’' && w[:i] != "Mc" && w[:i] != "Mac" {
+				return false
+			}
+		}
+		prev = r
+	}
+	return lower > 0
+}
+
+// isInitial reports whether w is a single capital letter followed by a dot
+// ("Thomas A. Schmidt").
+func isInitial(text string, w word) bool {
+	return utf8.RuneCountInString(w.text) == 1 && isUpperInitial(w.text) &&
+		w.end < len(text) && text[w.end] == '.'
+}
+
+// nameGap reports whether two words of a name are separated only by blanks
+// on the same line, or by the dot of an initial followed by blanks.
+func nameGap(text string, prev, next word) bool {
+	sep := text[prev.end:next.start]
+	if isInitial(text, prev) {
+		sep = sep[1:]
+	}
+	return sep != "" && strings.Trim(sep, " \t") == ""
+}
+
+// sentenceInitial reports whether the word at offset start begins a
+// sentence or line, where capitalization says nothing about proper nouns.
+func sentenceInitial(text string, start int) bool {
+	before := strings.TrimRight(text[:start], " \t\"'„“«(")
+	if before == "" {
+		return true
+	}
+	last, _ := utf8.DecodeLastRuneInString(before)
+	return strings.ContainsRune(".!?:\n\r•-", last)
+}
@@ -0,0 +1,113 @@
+package scanner
+
+import "testing"
+
+func TestNameGazetteer_TruePositives(t *testing.T) {
+	s := DefaultScanner(nil)
+	cases := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"DE sentence start", "Thomas Schmidt rief gestern an.", "Thomas Schmidt"},
+		{"DE mid-sentence", "Das Essen mit Peter Kowalczyk war gut.", "Peter Kowalczyk"},
+		{"middle name and particle", "Gestern rief Anna Maria de Vries an.", "Anna Maria de Vries"},
+		{"middle initial", "Signed by Thomas A. Schmidt today.", "Thomas A. Schmidt"},
+		{"two surnames", "José García López llamó ayer.", "José García López"},
+		{"FR compound first name", "Jean-Pierre Dubois a appelé.", "Jean-Pierre Dubois"},
+		{"surname first", "Teilnehmer: Schmidt, Thomas", "Schmidt, Thomas"},
+		{"ambiguous first name with surname", "We spoke to Will Smith yesterday.", "Will Smith"},
+		{"ambiguous surname", "Gestern war Anna Koch hier.", "Anna Koch"},
+		{"unknown surname", "Am Montag kommt Paul Wegener vorbei.", "Paul Wegener"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			entities := s.Scan(tc.input)
+			if !hasEntityWithText(entities, "PERSON", tc.want) {
+				t.Errorf("PERSON %q not found in %q, got %v", tc.want, tc.input, entities)
+			}
+		})
+	}
+}
+
+func TestNameGazetteer_TrueNegatives(t *testing.T) {
+	s := NewNameScanner()
+	cases := []struct {
+		name  string
+		input string
+	}{
+		{"ambiguous first name alone", "Will you call me tomorrow?"},
+		{"ambiguous first name, unknown word", "Mark Twain wrote it."},
+		{"ambiguous pair at sentence start", "Frank Koch kommt."},
+		{"stop-listed noun", "Thomas Straße ist gesperrt."},
+		{"calendar word", "Paul Montag"},
+		{"sentence boundary", "Wir danken Paul. Heute gehen wir."},
+		{"line break", "Thomas\nSchmidt"},
+		{"lowercase", "thomas schmidt"},
+		{"all caps", "THOMAS SCHMIDT"},
+		{"email", "thomas.schmidt@example.com"},
+		{"first name alone", "Anna kommt morgen."},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if entities := s.Scan(tc.input); len(entities) > 0 {
+				t.Errorf("false positive in %q: got %v", tc.input, entities)
+			}
+		})
+	}
+}
+
+func TestNameGazetteer_ScoreReflectsFrequency(t *testing.T) {
+	s := NewNameScanner()
+	score := func(text string) float64 {
+		entities := s.Scan(text)
+		if len(entities) != 1 {
+			t.Fatalf("want one entity in %q, got %v", text, entities)
+		}
+		return entities[0].Score
+	}
+
+	common := score("Gestern kam Thomas Schmidt.")
+	rare := score("Gestern kam Hedwig Dufresne.")
+	unknown := score("Gestern kam Thomas Wegener.")
+	initial := score("Thomas Wegener kam gestern.")
+
+	if common <= rare {
+		t.Errorf("common name score %.2f should exceed rare name score %.2f", common, rare)
+	}
+	if rare <= unknown {
+		t.Errorf("known surname score %.2f should exceed unknown surname score %.2f", rare, unknown)
+	}
+	if unknown <= initial {
+		t.Errorf("mid-sentence score %.2f should exceed sentence-start score %.2f", unknown, initial)
+	}
+}
+
+func TestNameGazetteer_TriggerWinsOnSameSpan(t *testing.T) {
+	s := DefaultScanner(nil)
+	entities := s.Scan("Sehr geehrter Herr Thomas Schmidt")
+	if len(entities) != 1 || entities[0].Detector != "regex" {
+		t.Errorf("want the trigger-based regex match, got %v", entities)
+	}
+}
+
+func TestIsNameShaped(t *testing.T) {
+	for w, want := range map[string]bool{
+		"Schmidt":             true,
+		"Müller-Lüdenscheidt": true,
+		"O'Brien":             true,
+		"D’Angelo":            true,
+		"McDonald":            true,
+		"MacArthur":           true,
+		"SCHMIDT":             false,
+		"PayPal":              false,
+		"JavaScript":          false,
+		"YouTube":             false,
+		"iPhone":              false,
+		"MaxMustermann":       false,
+	} {
+		if got := isNameShaped(w); got != want {
+			t.Errorf("isNameShaped(%q) = %v, want %v", w, got, want)
+		}
+	}
+}
@@ -14,24 +14,34 @@ func BuiltinScanners() []Scanner {
 
 	// Order matters for overlap: more specific patterns first.
 	scanners = append(scanners, secretScanners()...)
+	scanners = append(scanners, mrzScanners()...)
 	scanners = append(scanners, emailScanners()...)
 	scanners = append(scanners, urlScanners()...)
 	scanners = append(scanners, ibanScanners()...)
+	scanners = append(scanners, bankAccountScanners()...)
 	scanners = append(scanners, creditCardScanners()...)
 	scanners = append(scanners, ssnScanners()...)
+	scanners = append(scanners, americasScanners()...)
+	scanners = append(scanners, apacScanners()...)
+	scanners = append(scanners, ukScanners()...)
 	scanners = append(scanners, macAddressScanners()...)
+	scanners = append(scanners, deviceIDScanners()...)
 	scanners = append(scanners, phoneScanners()...)
 	scanners = append(scanners, dateScanners()...)
 	scanners = append(scanners, ipScanners()...)
 	scanners = append(scanners, medicalScanners()...)
+	scanners = append(scanners, sensitiveCategoryScanners()...)
 	scanners = append(scanners, ageScanners()...)
+	scanners = append(scanners, legalReferenceScanners()...)
 	scanners = append(scanners, idNumberScanners()...)
 	scanners = append(scanners, taxNumberScanners()...)
 	scanners = append(scanners, businessIDScanners()...)
 	scanners = append(scanners, orgScanners()...)
 	scanners = append(scanners, financialScanners()...)
 	scanners = append(scanners, addressScanners()...)
+	scanners = append(scanners, locationScanners()...)
 	scanners = append(scanners, personScanners()...)
+	scanners = append(scanners, nameScanners()...)
 
 	return scanners
 }
@@ -64,6 +74,8 @@ func ssnScanners() []Scanner {
 		NewRegexScanner(
 			regexp.MustCompile(`\b[A-CEGHJ-PR-TW-Z][A-CEGHJ-NPR-TW-Z]\s?\d{2}\s?\d{2}\s?\d{2}\s?[A-D]\b`),
 			"SSN", 0.90,
+			WithValidator(validateNINO),
+			WithSubtype("nino"),
 		),
 		// French INSEE: 1 85 12 75 108 042 36
 		NewRegexScanner(
@@ -226,28 +238,91 @@ func medicalScanners() []Scanner {
 			regexp.MustCompile(`(?i)(?:Diagnose|ICD|diagnosis|diagnostic)[:\s]+([A-Z]\d{2}(?:\.\d{1,4})?)`),
 			"MEDICAL", 0.90,
 			WithExtractGroup(1),
+			WithSubtype("icd10"),
 		),
 		// Blood pressure: 120/80 mmHg
 		NewRegexScanner(
 			regexp.MustCompile(`\b\d{2,3}/\d{2,3}\s?(?:mmHg|mm\s?Hg)\b`),
 			"MEDICAL", 0.90,
+			WithSubtype("blood_pressure"),
 		),
 		// Lab values with units
 		NewRegexScanner(
 			regexp.MustCompile(`\b\d{1,4}(?:[.,]\d{1,2})?\s?(?:mg/dL|mmol/L|g/dL|mL/min|ng/mL|ng/L|µg/L|U/L|IU/L|pg/mL|µmol/L)\b`),
 			"MEDICAL", 0.85,
+			WithSubtype("lab_value"),
 		),
 		// BMI values (context-triggered)
 		NewRegexScanner(
 			regexp.MustCompile(`(?i)(?:BMI|Body Mass Index)[:\s]+(\d{2}(?:[.,]\d{1,2})?)`),
 			"MEDICAL", 0.85,
 			WithExtractGroup(1),
+			WithSubtype("bmi"),
 		),
 		// ICD-10 codes standalone in parentheses: (I21.0), (E11.65)
 		NewRegexScanner(
 			regexp.MustCompile(`\(([A-Z]\d{2}(?:\.\d{1,4})?)\)`),
 			"MEDICAL", 0.85,
 			WithExtractGroup(1),
+			WithSubtype("icd10"),
+		),
+		// Medications from the embedded lexicon, with dosage if present
+		NewMedicationScanner(),
+		// ATC codes (context-triggered): C07, C07AB, C07AB02
+		NewRegexScanner(
+			regexp.MustCompile(`(?i:\bATC(?:[- ]?(?:Code|Kode))?)[:\s]+([A-Z]\d{2}(?:[A-Z]{1,2}(?:\d{2})?)?)\b`),
+			"MEDICAL", 0.90,
+			WithExtractGroup(1),
+			WithValidator(validateATC),
+			WithSubtype("atc"),
+		),
+		// ATC codes standalone, full five levels only: C07AB02
+		NewRegexScanner(
+			regexp.MustCompile(`\b[A-Z]\d{2}[A-Z]{2}\d{2}\b`),
+			"MEDICAL", 0.75,
+			WithValidator(validateATC),
+			WithSubtype("atc"),
+		),
+		// OPS procedure codes (context-triggered): 5-470.11, 8-98f.10, 1-632
+		NewRegexScanner(
+			regexp.MustCompile(`(?i:\bOPS(?:[- ]?(?:Code|Kode|Schlüssel))?)[:\s]+(\d-\d{2}[\da-z](?:\.[\da-z]{1,2})?)\b`),
+			"MEDICAL", 0.90,
+			WithExtractGroup(1),
+			WithValidator(validateOPS),
+			WithSubtype("ops"),
+		),
+		// CPT procedure codes (context-triggered): 99213, 3008F, 0042T
+		NewRegexScanner(
+			regexp.MustCompile(`(?i:\bCPT(?:[- ]?(?:Code|®))?)[:\s#]+(\d{4}[\dFT])\b`),
+			"MEDICAL", 0.90,
+			WithExtractGroup(1),
+			WithValidator(validateCPT),
+			WithSubtype("cpt"),
+		),
+		// DE: Krankenversichertennummer (context-triggered): A123456780
+		NewRegexScanner(
+			regexp.MustCompile(`(?i:Krankenversichertennummer|Versichertennummer|Versicherten-?Nr\.?|\bKVNR|\bKV-Nr\.?)[:\s]+([A-Z]\d{9})\b`),
+			"MEDICAL", 0.95,
+			WithExtractGroup(1),
+			WithValidator(validateKVNR),
+			WithSubtype("kvnr"),
+		),
+		// UK: NHS number (context-triggered): 943 476 5919; Northern Ireland
+		// Health and Care Numbers use the same check digit
+		NewRegexScanner(
+			regexp.MustCompile(`(?i:\bNHS(?:[ \t-]+(?:No\.?|Nr\.?|Number|#))?|\bHealth\s+(?:and|&)\s+Care\s+Number|\bH&C(?:[ \t]+(?:No\.?|Number))?)[:\s]+(\d{3}[ \-]?\d{3}[ \-]?\d{4})\b`),
+			"MEDICAL", 0.95,
+			WithExtractGroup(1),
+			WithValidator(validateNHS),
+			WithSubtype("nhs"),
+		),
+		// US: National Provider Identifier (context-triggered): 1234567893
+		NewRegexScanner(
+			regexp.MustCompile(`(?i:\bNPI(?:[ \t]+(?:No\.?|Number|#))?)[:\s#]+([12]\d{9})\b`),
+			"MEDICAL", 0.95,
+			WithExtractGroup(1),
+			WithValidator(validateNPI),
+			WithSubtype("npi"),
 		),
 	}
 }
@@ -588,6 +663,92 @@ func macAddressScanners() []Scanner {
 	}
 }
 
+// --- DEVICE_ID ---
+
+func deviceIDScanners() []Scanner {
+	// Optional quote and key/value separator used in logs and JSON payloads:
+	// "IMEI: 35…", imei=35…, "idfa": "…"
+	kv := `["']?[ \t]*[:=][ \t]*["']?`
+
+	uuid := `[0-9A-Fa-f]{8}-[0-9A-Fa-f]{4}-[0-9A-Fa-f]{4}-[0-9A-Fa-f]{4}-[0-9A-Fa-f]{12}`
+
+	// Session cookies of common web frameworks and analytics tools.
+	cookieNames := `(?:JSESSIONID|PHPSESSID|ASP\.NET_SessionId|ASPSESSIONID[A-Z]{8}|CFID|CFTOKEN` +
+		`|connect\.sid|laravel_session|ci_session|_session_id|sessionid|session_id|sessid` +
+		`|_ga|_gid|_fbp|_gcl_au|__Host-[\w\-]+|__Secure-[\w\-]+)`
+
+	return []Scanner{
+		// IMEI (context-triggered): 15 digits, optionally grouped 2-6-6-1, Luhn check
+		NewRegexScanner(
+			regexp.MustCompile(`(?i)\bIMEI`+kv+`(\d{2}[ \-]?\d{6}[ \-]?\d{6}[ \-]?\d)\b`),
+			"DEVICE_ID", 0.95,
+			WithExtractGroup(1),
+			WithValidator(validateLuhn),
+			WithSubtype("imei"),
+		),
+		// IMEI standalone in the canonical grouped form: 35-209900-176148-1
+		NewRegexScanner(
+			regexp.MustCompile(`\b\d{2}-\d{6}-\d{6}-\d\b`),
+			"DEVICE_ID", 0.85,
+			WithValidator(validateLuhn),
+			WithSubtype("imei"),
+		),
+		// IMEISV (context-triggered): 16 digits, the software version replaces the check digit
+		NewRegexScanner(
+			regexp.MustCompile(`(?i)\bIMEI[ \-]?SV`+kv+`(\d{2}[ \-]?\d{6}[ \-]?\d{6}[ \-]?\d{2})\b`),
+			"DEVICE_ID", 0.90,
+			WithExtractGroup(1),
+			WithSubtype("imeisv"),
+		),
+		// MEID (context-triggered): 14 hex digits
+		NewRegexScanner(
+			regexp.MustCompile(`(?i)\bMEID`+kv+`([0-9A-F]{14})\b`),
+			"DEVICE_ID", 0.90,
+			WithExtractGroup(1),
+			WithSubtype("meid"),
+		),
+		// Advertising IDs (context-triggered): IDFA/IDFV (iOS), GAID/AAID (Android)
+		NewRegexScanner(
+			regexp.MustCompile(`(?i)\b(?:IDFA|IDFV|GAID|AAID|ADID|advertising[ _\-]?id|ad[_\-]id|google[ _\-]?ad[ _\-]?id|Werbe-?ID)`+kv+`(`+uuid+`)`),
+			"DEVICE_ID", 0.95,
+			WithExtractGroup(1),
+			WithValidator(func(s string) bool {
+				// The all-zero ID is what devices report when tracking is limited.
+				return strings.Trim(s, "0-") != ""
+			}),
+			WithSubtype("advertising_id"),
+		),
+		// Android ID (context-triggered): 16 hex digits
+		NewRegexScanner(
+			regexp.MustCompile(`(?i)\bandroid[ _\-]?id`+kv+`([0-9a-f]{16})\b`),
+			"DEVICE_ID", 0.90,
+			WithExtractGroup(1),
+			WithSubtype("android_id"),
+		),
+		// ICCID (context-triggered): 19–20 digits starting with the telecom prefix 89
+		NewRegexScanner(
+			regexp.MustCompile(`(?i)\b(?:ICCID|SIM(?:[ \-]?(?:card|Karte|number|Nummer|serial))?)`+kv+`(89\d{17,18})\b`),
+			"DEVICE_ID", 0.95,
+			WithExtractGroup(1),
+			WithSubtype("iccid"),
+		),
+		// ICCID standalone: 19 digits, 89 prefix, Luhn check
+		NewRegexScanner(
+			regexp.MustCompile(`\b89\d{17}\b`),
+			"DEVICE_ID", 0.85,
+			WithValidator(validateLuhn),
+			WithSubtype("iccid"),
+		),
+		// Session cookies: the value after a well-known cookie name
+		NewRegexScanner(
+			regexp.MustCompile(`\b`+cookieNames+`=([^;\s"',&]{6,})`),
+			"DEVICE_ID", 0.90,
+			WithExtractGroup(1),
+			WithSubtype("session_cookie"),
+		),
+	}
+}
+
 // --- PERSON ---
 
 // Unicode-aware name component: uppercase letter followed by lowercase letters,
@@ -602,77 +763,86 @@ const nameParticle = `(?:de|van|der|von|di|del|della|le|la|da|dos|das|du|ten|ter
 // Full name: 2-4 name components with optional particles between them.
 const fullName = namePattern + `(?:[ \t]+(?:` + nameParticle + `[ \t]+)*` + namePattern + `){1,3}`
 
+// personTriggers are the words that introduce a name, with the role of the
+// person they imply. Longer/more specific patterns first to avoid partial
+// matches.
+var personTriggers = []struct{ pattern, role string }{
+	// Multi-word triggers first
+	{`Dr\.\s?med\.`, RoleClinician}, {`de\s+heer`, RoleTitle},
+	{`mein Freund`, RoleFamily}, {`meine Freundin`, RoleFamily},
+	{`meinen Patienten`, RolePatient}, {`meiner Patientin`, RolePatient},
+	{`my friend`, RoleFamily}, {`my colleague`, RoleBusiness}, {`my patient`, RolePatient},
+	{`mon ami`, RoleFamily}, {`mon amie`, RoleFamily},
+	// Family triggers (EN, DE, FR, ES)
+	{`his wife`, RoleFamily}, {`her husband`, RoleFamily}, {`his spouse`, RoleFamily}, {`her spouse`, RoleFamily},
+	{`seine Frau`, RoleFamily}, {`ihr Mann`, RoleFamily}, {`Ehefrau`, RoleFamily}, {`Ehemann`, RoleFamily},
+	{`son épouse`, RoleFamily}, {`sa femme`, RoleFamily}, {`son mari`, RoleFamily},
+	{`su esposa`, RoleFamily}, {`su esposo`, RoleFamily},
+	// German role triggers
+	{`Antragsteller(?:in)?`, RoleLegalParty}, {`Sachbearbeiter(?:in)?`, RoleBusiness}, {`Bearbeiter(?:in)?`, RoleBusiness},
+	{`Konsiliarius`, RoleClinician},
+	{`Leiter(?:in)?`, RoleBusiness}, {`Geschäftsführer(?:in)?`, RoleBusiness}, {`Inhaber(?:in)?`, RoleBusiness},
+	{`Direktor(?:in)?`, RoleBusiness}, {`Vorstand`, RoleBusiness}, {`Vorsitzende[r]?`, RoleBusiness},
+	{`Mitarbeiter(?:in)?`, RoleBusiness}, {`Angestellte[r]?`, RoleBusiness},
+	{`Vorgesetzte[r]?`, RoleBusiness},
+	// German medical role triggers
+	{`Oberarzt`, RoleClinician}, {`Oberärztin`, RoleClinician}, {`Chefarzt`, RoleClinician}, {`Chefärztin`, RoleClinician},
+	{`Arzt`, RoleClinician}, {`Ärztin`, RoleClinician}, {`Krankenschwester`, RoleClinician}, {`Pfleger(?:in)?`, RoleClinician},
+	// German legal/professional triggers
+	{`Rechtsanwalt`, RoleLegalParty}, {`Rechtsanwältin`, RoleLegalParty}, {`Notar(?:in)?`, RoleLegalParty},
+	{`Steuerberater(?:in)?`, RoleBusiness}, {`Buchhalter(?:in)?`, RoleBusiness},
+	// English role triggers
+	{`Employee`, RoleBusiness}, {`Supervisor`, RoleBusiness}, {`Manager`, RoleBusiness},
+	{`Attorney`, RoleLegalParty}, {`Solicitor`, RoleLegalParty}, {`Barrister`, RoleLegalParty},
+	{`Accountant`, RoleBusiness}, {`Nurse`, RoleClinician},
+	// Legal triggers (EN, DE)
+	{`plaintiff`, RoleLegalParty}, {`defendant`, RoleLegalParty}, {`witness`, RoleLegalParty},
+	{`Kläger(?:in)?`, RoleLegalParty}, {`Beklagte[r]?`, RoleLegalParty},
+	{`Zeuge`, RoleLegalParty}, {`Zeugin`, RoleLegalParty},
+	// Titles (more specific first)
+	{`Dott\.?\s?ssa`, RoleTitle}, {`Dott\.?`, RoleTitle}, {`Dra\.?`, RoleTitle},
+	{`Prof\.?`, RoleTitle}, {`Dr\.?`, RoleTitle},
+	// German
+	{`Herr`, RoleTitle}, {`Frau`, RoleTitle}, {`Patient(?:in)?`, RolePatient}, {`Kollege`, RoleBusiness}, {`Kollegin`, RoleBusiness},
+	// French
+	{`Monsieur`, RoleTitle}, {`Madame`, RoleTitle}, {`Mademoiselle`, RoleTitle},
+	// English
+	{`Mr\.?`, RoleTitle}, {`Mrs\.?`, RoleTitle}, {`Ms\.?`, RoleTitle}, {`colleague`, RoleBusiness},
+	// Dutch
+	{`Meneer`, RoleTitle}, {`Mevrouw`, RoleTitle},
+	// Italian
+	{`Signor(?:a)?`, RoleTitle},
+	// Spanish
+	{`Señor(?:a)?`, RoleTitle},
+	// Polish
+	{`Pan`, RoleTitle}, {`Pani`, RoleTitle},
+	// Czech
+	{`Pán`, RoleTitle}, {`Paní`, RoleTitle},
+	// Finnish
+	{`Herra`, RoleTitle}, {`Rouva`, RoleTitle},
+	// Romanian
+	{`Domnul`, RoleTitle}, {`Doamna`, RoleTitle},
+	// Croatian
+	{`Gospodin`, RoleTitle}, {`Gospođa`, RoleTitle},
+	// Portuguese
+	{`Senhor(?:a)?`, RoleTitle},
+	// Greek (Latin transliteration)
+	{`Kyrios`, RoleTitle}, {`Kyria`, RoleTitle},
+}
+
 func personScanners() []Scanner {
 	// Context-triggered: keyword + CapFirst CapLast
-	// Longer/more specific patterns first to avoid partial matches.
-	triggers := []string{
-		// Multi-word triggers first
-		`Dr\.\s?med\.`, `de\s+heer`,
-		`mein Freund`, `meine Freundin`,
-		`meinen Patienten`, `meiner Patientin`,
-		`my friend`, `my colleague`, `my patient`,
-		`mon ami`, `mon amie`,
-		// Family triggers (EN, DE, FR, ES)
-		`his wife`, `her husband`, `his spouse`, `her spouse`,
-		`seine Frau`, `ihr Mann`, `Ehefrau`, `Ehemann`,
-		`son épouse`, `sa femme`, `son mari`,
-		`su esposa`, `su esposo`,
-		// German role triggers
-		`Antragsteller(?:in)?`, `Sachbearbeiter(?:in)?`, `Bearbeiter(?:in)?`,
-		`Konsiliarius`,
-		`Leiter(?:in)?`, `Geschäftsführer(?:in)?`, `Inhaber(?:in)?`,
-		`Direktor(?:in)?`, `Vorstand`, `Vorsitzende[r]?`,
-		`Mitarbeiter(?:in)?`, `Angestellte[r]?`,
-		`Vorgesetzte[r]?`,
-		// German medical role triggers
-		`Oberarzt`, `Oberärztin`, `Chefarzt`, `Chefärztin`,
-		`Arzt`, `Ärztin`, `Krankenschwester`, `Pfleger(?:in)?`,
-		// German legal/professional triggers
-		`Rechtsanwalt`, `Rechtsanwältin`, `Notar(?:in)?`,
-		`Steuerberater(?:in)?`, `Buchhalter(?:in)?`,
-		// English role triggers
-		`Employee`, `Supervisor`, `Manager`,
-		`Attorney`, `Solicitor`, `Barrister`,
-		`Accountant`, `Nurse`,
-		// Legal triggers (EN, DE)
-		`plaintiff`, `defendant`, `witness`,
-		`Kläger(?:in)?`, `Beklagte[r]?`,
-		`Zeuge`, `Zeugin`,
-		// Titles (more specific first)
-		`Dott\.?\s?ssa`, `Dott\.?`, `Dra\.?`,
-		`Prof\.?`, `Dr\.?`,
-		// German
-		`Herr`, `Frau`, `Patient(?:in)?`, `Kollege`, `Kollegin`,
-		// French
-		`Monsieur`, `Madame`, `Mademoiselle`,
-		// English
-		`Mr\.?`, `Mrs\.?`, `Ms\.?`, `colleague`,
-		// Dutch
-		`Meneer`, `Mevrouw`,
-		// Italian
-		`Signor(?:a)?`,
-		// Spanish
-		`Señor(?:a)?`,
-		// Polish
-		`Pan`, `Pani`,
-		// Czech
-		`Pán`, `Paní`,
-		// Finnish
-		`Herra`, `Rouva`,
-		// Romanian
-		`Domnul`, `Doamna`,
-		// Croatian
-		`Gospodin`, `Gospođa`,
-		// Portuguese
-		`Senhor(?:a)?`,
-		// Greek (Latin transliteration)
-		`Kyrios`, `Kyria`,
+	triggers := make([]string, len(personTriggers))
+	for i, t := range personTriggers {
+		triggers[i] = t.pattern
 	}
 
 	triggerGroup := `(?:` + strings.Join(triggers, `|`) + `)`
 	// Use (?i:...) only for the trigger group, keep name pattern case-sensitive.
 	// Allow optional colon/comma between trigger and name (e.g. "Antragsteller: Thomas Schmidt").
-	contextPattern := `(?i:` + triggerGroup + `)[: \t]+(` + fullName + `)`
+	// Triggers may be chained ("Oberarzt Dr. Thomas Schmidt"); they are
+	// captured so that their role can be recorded on the entity.
+	contextPattern := `(?i:(` + triggerGroup + `(?:[ \t]+` + triggerGroup + `)*))[: \t]+(` + fullName + `)`
 
 	// Verb-triggered: "told/asked/called/emailed Name Name"
 	verbs := `(?i:told|asked|called|emailed|contacted|met|visited|informed)`
@@ -689,7 +859,8 @@ func personScanners() []Scanner {
 		NewRegexScanner(
 			regexp.MustCompile(contextPattern),
 			"PERSON", 0.95,
-			WithExtractGroup(1),
+			WithExtractGroup(2),
+			WithRole(1, personTriggerRole),
 		),
 		NewRegexScanner(
 			regexp.MustCompile(verbPattern),
@@ -709,16 +880,6 @@ func personScanners() []Scanner {
 	}
 }
 
-// --- EMAIL ---
-
-func emailScanners() []Scanner {
-	// RFC 5322 simplified with unicode support for DACH region.
-	pattern := `[a-zA-Z0-9._%+\-àáâãäåæçèéêëìíîïðñòóôõöøùúûüýþß]+@[a-zA-Z0-9.\-]+\.[a-zA-Z]{2,}`
-	return []Scanner{
-		NewRegexScanner(regexp.MustCompile(pattern), "EMAIL", 0.99),
-	}
-}
-
 // --- PHONE ---
 
 // ibanPrefixRe matches the leading portion of an IBAN (country code + check digits +
@@ -875,7 +1036,9 @@ func creditCardScanners() []Scanner {
 	}
 }
 
-// validateLuhn performs the Luhn algorithm check.
+// validateLuhn performs the Luhn algorithm check on the digits of s. Their
+// count is left to the pattern: cards have 13–19, Saudi and Israeli IDs 10
+// and 9.
 func validateLuhn(s string) bool {
 	// Extract digits only.
 	var digits []int
@@ -885,7 +1048,7 @@ func validateLuhn(s string) bool {
 		}
 	}
 
-	if len(digits) < 13 || len(digits) > 19 {
+	if len(digits) < 2 {
 		return false
 	}
 
@@ -1159,21 +1322,29 @@ func financialScanners() []Scanner {
 	// BIC/SWIFT standalone with known EU country codes
 	bicStandalone := `\b[A-Z]{4}(?:AT|DE|CH|FR|IT|ES|NL|BE|IE|GB|LU|PT|PL|CZ|HU|SK|SI|HR|BG|RO|LT|LV|EE|FI|SE|DK|NO|LI|MT|CY|GR)[A-Z0-9]{2}(?:[A-Z0-9]{3})?\b`
 
+	// Thousands groups separated by a dot, space, no-break space or narrow
+	// no-break space (15 000, 15\u00a0000, 15\u202f000), and the space before
+	// a trailing currency. \s matches neither kind of no-break space, and \b
+	// does not follow a non-ASCII letter (zł, Kč), so those currencies end
+	// without one.
+	spaceGroups := `(?:[ .\x{00A0}\x{202F}]\d{3})*`
+	currencySpace := `[ \x{00A0}\x{202F}]?`
+
 	// Polish Złoty: 1 500,00 zł or 1500.00 PLN
-	plnSuffix := `\d{1,3}(?:[\s.]\d{3})*,\d{2}\s?(?:zł|PLN)\b`
-	plnCode := `\bPLN\s?\d{1,3}(?:[\s.]\d{3})*,\d{2}`
+	plnSuffix := `\b\d{1,3}` + spaceGroups + `,\d{2}` + currencySpace + `(?:zł|PLN\b)`
+	plnCode := `\bPLN\s?\d{1,3}` + spaceGroups + `,\d{2}`
 
 	// Czech Koruna: 15 000,00 Kč or 15000 CZK
-	czkSuffix := `\d{1,3}(?:[\s.]\d{3})*,?\d{0,2}\s?(?:Kč|CZK)\b`
+	czkSuffix := `\b\d{1,3}` + spaceGroups + `,?\d{0,2}` + currencySpace + `(?:Kč|CZK\b)`
 
 	// Hungarian Forint: 1 500 000 Ft or HUF
-	hufSuffix := `\d{1,3}(?:[\s.]\d{3})*\s?(?:Ft|HUF)\b`
+	hufSuffix := `\b\d{1,3}` + spaceGroups + currencySpace + `(?:Ft|HUF)\b`
 
 	// Romanian Leu: 15.000,00 lei or RON
 	ronSuffix := `\d{1,3}(?:\.\d{3})*,\d{2}\s?(?:lei|RON)\b`
 
 	// Swedish/Norwegian/Danish Krona/Krone: 15 000,00 kr
-	sekSuffix := `\d{1,3}(?:[\s.]\d{3})*,\d{2}\s?(?:kr\.?|SEK|NOK|DKK)\b`
+	sekSuffix := `\b\d{1,3}` + spaceGroups + `,\d{2}` + currencySpace + `(?:kr\.?|SEK|NOK|DKK)\b`
 
 	return []Scanner{
 		NewRegexScanner(regexp.MustCompile(eurPrefix), "FINANCIAL", 0.90),
@@ -0,0 +1,256 @@
+package scanner
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// --- Document report ---
+
+// Sensitivity tiers, from least to most sensitive.
+const (
+	TierNone    = "none"
+	TierLow     = "low"     // dates, ages, places, organisations, URLs
+	TierMedium  = "medium"  // names, contact details, addresses, device and legal identifiers
+	TierHigh    = "high"    // secrets, financial and national identifiers
+	TierSpecial = "special" // health and other special categories (GDPR Art. 9)
+)
+
+// tierRanks orders the tiers.
+var tierRanks = map[string]int{TierNone: 0, TierLow: 1, TierMedium: 2, TierHigh: 3, TierSpecial: 4}
+
+// typeTiers assigns every built-in entity type a tier. Other types, such as
+// those of custom patterns, are TierMedium.
+var typeTiers = map[string]string{
+	"DATE": TierLow, "AGE": TierLow, "LOCATION": TierLow, "ORG": TierLow, "URL": TierLow, QuasiIdentifierType: TierLow,
+	"PERSON": TierMedium, "EMAIL": TierMedium, "PHONE": TierMedium, "ADDRESS": TierMedium,
+	"IP_ADDRESS": TierMedium, "MAC_ADDRESS": TierMedium, "DEVICE_ID": TierMedium, "LEGAL_REFERENCE": TierMedium,
+	"IBAN": TierHigh, "BANK_ACCOUNT": TierHigh, "CREDIT_CARD": TierHigh, "SSN": TierHigh,
+	"ID_NUMBER": TierHigh, "FINANCIAL": TierHigh, "SECRET": TierHigh,
+	"MEDICAL": TierSpecial, "SENSITIVE_CATEGORY": TierSpecial,
+}
+
+// tierWeights are the default weights of the entity types in a tier.
+var tierWeights = map[string]float64{TierLow: 2, TierMedium: 5, TierHigh: 15, TierSpecial: 25}
+
+// tierFloors are the lowest score of a document that contains the tier.
+var tierFloors = map[string]int{TierHigh: 40, TierSpecial: 60}
+
+const (
+	// reportScale is the number of points at which the score reaches 63,
+	// 1 - 1/e of the maximum.
+	reportScale = 50
+	// individualWeight and reidentificationWeight are the points added per
+	// individual and for a re-identification risk of 1.
+	individualWeight       = 5
+	reidentificationWeight = 30
+)
+
+// Report verdicts.
+const (
+	VerdictLow      = "low"
+	VerdictMedium   = "medium"   // Score of 25 or more
+	VerdictHigh     = "high"     // Score of 50 or more
+	VerdictCritical = "critical" // Score of 75 or more
+)
+
+// Report is the aggregated PII risk of one document.
+type Report struct {
+	// Characters is the length of the text in characters.
+	Characters int `json:"characters"`
+	// Entities counts the entities found, without test data.
+	Entities int `json:"entities"`
+	// Density is the number of entities per 1,000 characters.
+	Density float64        `json:"density"`
+	Types   map[string]int `json:"types,omitempty"`
+	// HighestTier is the most sensitive tier present, one of the Tier*
+	// constants.
+	HighestTier string `json:"highest_tier"`
+	// Secrets is set when the text contains a SECRET, including
+	// credentials and tokens in URLs.
+	Secrets bool `json:"secrets"`
+	// SpecialCategories lists the special categories of data present:
+	// the subtypes of SENSITIVE_CATEGORY, and "health" for MEDICAL data.
+	SpecialCategories []string `json:"special_categories,omitempty"`
+	// Individuals is the number of distinct individuals (identity clusters).
+	Individuals int `json:"individuals"`
+	// Reidentification is the re-identification risk of the document's
+	// quasi-identifiers (see AssessRisk).
+	Reidentification float64 `json:"reidentification"`
+	// Score is the overall risk from 0 to 100.
+	Score   int    `json:"score"`
+	Verdict string `json:"verdict"` // one of the Verdict* constants
+	// Explanation lists what contributed to the score.
+	Explanation []string `json:"explanation"`
+}
+
+// ReportOption configures NewReport.
+type ReportOption func(*reportOptions)
+
+type reportOptions struct {
+	weights map[string]float64
+}
+
+// WithReportWeights overrides the weight of entity types in the score, e.g.
+// {"SECRET": 40, "DATE": 0}. Types not listed keep the default weight of
+// their tier: 2 (low), 5 (medium), 15 (high) or 25 (special). A weight of 0
+// leaves a type out of the score and the highest tier.
+func WithReportWeights(weights map[string]float64) ReportOption {
+	return func(o *reportOptions) { o.weights = weights }
+}
+
+// CheckReportWeights validates weights read from a config file.
+func CheckReportWeights(weights map[string]float64) error {
+	for typ, w := range weights {
+		if w < 0 || math.IsNaN(w) || math.IsInf(w, 0) {
+			return fmt.Errorf("weight %g for %s must be a non-negative number", w, typ)
+		}
+	}
+	return nil
+}
+
+// NewReport computes the report of a document from its text and the
+// entities Scan found in it.
+//
+// Every distinct value (by type and text; later mentions of a person count
+// once) adds the weight of its type to the document's points, every
+// individual 5 points and the re-identification risk up to 30. The score is
+// 100·(1 − e^(−points/50)), but at least 40 with a high-tier entity and 60
+// with special-category data. Test data is ignored.
+func NewReport(text string, entities []Entity, opts ...ReportOption) Report {
+	var o reportOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	weight := func(typ string) float64 {
+		if w, ok := o.weights[typ]; ok {
+			return w
+		}
+		return tierWeights[tierOf(typ)]
+	}
+
+	text = Normalize(text)
+	var real []Entity
+	for _, e := range entities {
+		if !e.TestData {
+			real = append(real, e)
+		}
+	}
+	r := Report{
+		Characters:  utf8.RuneCountInString(text),
+		Entities:    len(real),
+		HighestTier: TierNone,
+	}
+	if r.Characters > 0 {
+		r.Density = math.Round(float64(len(real))*1000/float64(r.Characters)*10) / 10
+	}
+
+	points := 0.0
+	typePoints := make(map[string]float64)
+	seen := make(map[string]bool)
+	special := make(map[string]bool)
+	add := func(e Entity) {
+		if w := weight(e.Type); w > 0 && tierRanks[tierOf(e.Type)] > tierRanks[r.HighestTier] {
+			r.HighestTier = tierOf(e.Type)
+		}
+		switch e.Type {
+		case "SECRET":
+			r.Secrets = true
+		case "MEDICAL":
+			special[SensitiveHealth] = true
+		case "SENSITIVE_CATEGORY":
+			special[e.Subtype] = true
+		}
+		key := e.Type + "\x00" + strings.ToLower(personName(e))
+		if !seen[key] {
+			seen[key] = true
+			points += weight(e.Type)
+			typePoints[e.Type] += weight(e.Type)
+		}
+	}
+	for _, e := range real {
+		if r.Types == nil {
+			r.Types = make(map[string]int)
+		}
+		r.Types[e.Type]++
+		add(e)
+		// The sensitive components of a URL count as entities of their own.
+		for _, p := range e.Parts {
+			add(p)
+		}
+	}
+	for c := range special {
+		r.SpecialCategories = append(r.SpecialCategories, c)
+	}
+	sort.Strings(r.SpecialCategories)
+	r.Individuals = len(Clusters(real))
+	points += float64(r.Individuals * individualWeight)
+	r.Reidentification = AssessRisk(text, real).Score
+	points += r.Reidentification * reidentificationWeight
+
+	r.Score = int(math.Round(100 * (1 - math.Exp(-points/reportScale))))
+	r.Score = max(r.Score, tierFloors[r.HighestTier])
+	switch {
+	case r.Score >= 75:
+		r.Verdict = VerdictCritical
+	case r.Score >= 50:
+		r.Verdict = VerdictHigh
+	case r.Score >= 25:
+		r.Verdict = VerdictMedium
+	default:
+		r.Verdict = VerdictLow
+	}
+	r.Explanation = reportExplanation(r, typePoints)
+	return r
+}
+
+// tierOf returns the tier of an entity type.
+func tierOf(typ string) string {
+	if t, ok := typeTiers[typ]; ok {
+		return t
+	}
+	return TierMedium
+}
+
+// reportExplanation lists the contributions to a report's score, largest
+// first.
+func reportExplanation(r Report, typePoints map[string]float64) []string {
+	types := make([]string, 0, len(typePoints))
+	for t, p := range typePoints {
+		if p > 0 {
+			types = append(types, t)
+		}
+	}
+	sort.Slice(types, func(i, j int) bool {
+		if typePoints[types[i]] != typePoints[types[j]] {
+			return typePoints[types[i]] > typePoints[types[j]]
+		}
+		return types[i] < types[j]
+	})
+	var lines []string
+	if len(r.SpecialCategories) > 0 {
+		lines = append(lines, "special categories of data: "+strings.Join(r.SpecialCategories, ", "))
+	}
+	if r.Secrets {
+		lines = append(lines, "contains secrets or credentials")
+	}
+	if floor := tierFloors[r.HighestTier]; floor > 0 {
+		lines = append(lines, fmt.Sprintf("%s-tier data present: score at least %d", r.HighestTier, floor))
+	}
+	for _, t := range types {
+		lines = append(lines, fmt.Sprintf("%s (%s tier): %g points", t, tierOf(t), typePoints[t]))
+	}
+	if r.Individuals > 0 {
+		lines = append(lines, fmt.Sprintf("individuals: %d (%d points)", r.Individuals, r.Individuals*individualWeight))
+	}
+	if r.Reidentification > 0 {
+		lines = append(lines, fmt.Sprintf("re-identification risk %.0f%%: %.1f points", r.Reidentification*100, r.Reidentification*reidentificationWeight))
+	}
+	if len(lines) == 0 {
+		lines = append(lines, "no personal data found")
+	}
+	return lines
+}
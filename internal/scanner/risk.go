@@ -0,0 +1,335 @@
+package scanner
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// --- Re-identification risk ---
+
+// Kinds of quasi-identifiers: values that are harmless on their own but
+// single out a person in combination, like birth date, postcode and gender.
+const (
+	QuasiBirthDate  = "birth_date"
+	QuasiAge        = "age" // an age or a year of birth
+	QuasiPostcode   = "postcode"
+	QuasiCity       = "city"
+	QuasiGender     = "gender"
+	QuasiOccupation = "occupation"
+)
+
+// quasiKinds lists the kinds in the order they are reported.
+var quasiKinds = []string{QuasiBirthDate, QuasiAge, QuasiPostcode, QuasiCity, QuasiGender, QuasiOccupation}
+
+// quasiBits estimates how many bits of identifying information a value of
+// each kind carries: log2 of the number of values it can take, weighted by
+// how evenly a population spreads over them.
+var quasiBits = map[string]float64{
+	QuasiBirthDate:  15.2, // about 36,500 days over a hundred years
+	QuasiAge:        6.6,  // about a hundred ages or birth years
+	QuasiPostcode:   13.0, // 8,000 German to 40,000 US postal codes
+	QuasiCity:       9.0,  // a few hundred places most people live in
+	QuasiGender:     1.0,
+	QuasiOccupation: 8.0, // a few hundred common occupations
+}
+
+// quasiNames are the kinds as they appear in explanations.
+var quasiNames = map[string]string{
+	QuasiBirthDate:  "birth date",
+	QuasiAge:        "age",
+	QuasiPostcode:   "postcode",
+	QuasiCity:       "city",
+	QuasiGender:     "gender",
+	QuasiOccupation: "occupation",
+}
+
+const (
+	// riskPopulation is the population a combination of quasi-identifiers
+	// has to single a person out of: that of a large country.
+	riskPopulation = 80e6
+	// riskWindow is how many bytes apart quasi-identifiers may be to be
+	// read as describing the same person.
+	riskWindow = 300
+)
+
+// Risk levels.
+const (
+	RiskLow    = "low"
+	RiskMedium = "medium" // Score of 0.1 or more
+	RiskHigh   = "high"   // Score of 0.5 or more
+)
+
+// QuasiIdentifier is one value that helps to re-identify a person.
+type QuasiIdentifier struct {
+	Kind  string `json:"kind"` // one of the Quasi* constants
+	Start int    `json:"start"`
+	End   int    `json:"end"`
+	Text  string `json:"text"`
+	// Type is the type of the entity the value was read from, or
+	// QUASI_IDENTIFIER for gender and occupation words and bare postcodes
+	// that are not entities of their own.
+	Type string `json:"type"`
+}
+
+// RiskCombination is a set of quasi-identifiers of different kinds close
+// enough to each other to describe one person.
+type RiskCombination struct {
+	Kinds            []string          `json:"kinds"`
+	QuasiIdentifiers []QuasiIdentifier `json:"quasi_identifiers"`
+	// Bits is the estimated identifying information of the combination.
+	Bits float64 `json:"bits"`
+	// Score is the probability that no one else in riskPopulation shares
+	// the combination.
+	Score       float64 `json:"score"`
+	Explanation string  `json:"explanation"`
+}
+
+// Risk is the re-identification risk of a document.
+type Risk struct {
+	// Score is the highest Score of any combination, 0 if there is none.
+	Score            float64           `json:"score"`
+	Level            string            `json:"level"` // RiskLow, RiskMedium or RiskHigh
+	QuasiIdentifiers []QuasiIdentifier `json:"quasi_identifiers,omitempty"`
+	// Combinations are ordered by Score, highest first.
+	Combinations []RiskCombination `json:"combinations,omitempty"`
+	Explanation  string            `json:"explanation"`
+}
+
+// QuasiIdentifierType is the entity type of quasi-identifiers that are not
+// entities of their own, when they are redacted.
+const QuasiIdentifierType = "QUASI_IDENTIFIER"
+
+// birthContextRe matches the words that make a following DATE a birth date.
+var birthContextRe = regexp.MustCompile(`(?i)(?:\bgeb(?:oren|\.)|\bborn\b|\bbirth|\bDOB\b|\bD\.O\.B\.|Geburts|\bnée?[ \t]|\bnacid[oa]\b|\bnacimiento|\bnaissance|\bnat[oa]\b|\bnascita)[^\n]{0,24}$`)
+
+// genderRe matches words that state a person's gender: adjectives, nouns
+// and titles in English, German, French, Spanish and Italian.
+var genderRe = regexp.MustCompile(`(?i)(?:male|female|man|woman|boy|girl|non-binary|nonbinary|männlich|weiblich|divers|Mann|Frau|Junge|Mädchen|masculin|féminin|homme|femme|masculino|femenino|hombre|mujer|maschile|femminile|uomo|donna|Mr|Mrs|Ms|Herr|Herrn|Monsieur|Madame|Señor|Señora|Signor|Signora)`)
+
+// genderCodeRe matches a gender given as a code: "sex: F", "Geschlecht: w".
+var genderCodeRe = regexp.MustCompile(`(?i)\b(?:sex|gender|Geschlecht|sexe|sexo|sesso)[ \t]*[:=][ \t]*([mfwdx])\b`)
+
+// occupationRe matches common occupations.
+var occupationRe = regexp.MustCompile(`(?i)(?:teacher|nurse|engineer|lawyer|attorney|physician|police officer|firefighter|pilot|pharmacist|dentist|architect|accountant|journalist|judge|mayor|professor|farmer|electrician|plumber|mechanic|carpenter|cashier|programmer|soldier|priest|midwife|` +
+	`Lehrer(?:in)?|Krankenschwester|(?:Kranken|Alten)?pfleger(?:in)?|Ingenieur(?:in)?|Rechtsanwalt|Rechtsanwältin|Anwalt|Anwältin|Polizist(?:in)?|Feuerwehrmann|Feuerwehrfrau|Pilot(?:in)?|Apotheker(?:in)?|Zahnarzt|Zahnärztin|Arzt|Ärztin|Architekt(?:in)?|Steuerberater(?:in)?|Buchhalter(?:in)?|Journalist(?:in)?|Richter(?:in)?|Bürgermeister(?:in)?|Professor(?:in)?|Landwirt(?:in)?|Elektriker(?:in)?|Installateur(?:in)?|Mechaniker(?:in)?|Tischler(?:in)?|Schreiner(?:in)?|Kassierer(?:in)?|Erzieher(?:in)?|Friseur(?:in)?|Bäcker(?:in)?|Metzger(?:in)?|Verkäufer(?:in)?|Informatiker(?:in)?|Programmierer(?:in)?|Soldat(?:in)?|Pfarrer(?:in)?|Hebamme|` +
+	`enseignante?|infirmière|infirmier|ingénieure?|avocate?|médecin|pharmacienne|pharmacien|profesora?|enfermer[oa]|ingenier[oa]|abogad[oa]|médic[oa]|insegnante|infermier[ae]|ingegnere|avvocat[oa])`)
+
+// occupationContextRe matches an occupation named after a trigger such as
+// "von Beruf" or "works as a", for occupations outside occupationRe.
+var occupationContextRe = regexp.MustCompile(`(?i)(?:\bvon Beruf|\bBeruf:|\boccupation:|\bprofession:|\bjob title:|\bworks as an?|\barbeitet als|\btätig als|\bemployed as an?|\btravaille comme|\btrabaja como|\blavora come)[ \t]+(\p{L}[\p{L}\-]+)`)
+
+// postcodeContextRe matches a postcode after a keyword: "PLZ 10115", "zip 90210".
+var postcodeContextRe = regexp.MustCompile(`(?i)\b(?:PLZ|Postleitzahl|zip(?:[ \t]?code)?|post[ \t]?code|code postal|CAP)[:\s]+(\d{4,5}|[A-Z]{1,2}\d[A-Z\d]?[ \t]?\d[A-Z]{2})\b`)
+
+// AssessRisk estimates how easily the person a text is about can be
+// re-identified from its quasi-identifiers, even with names and contact
+// details redacted (Sweeney: birth date, ZIP code and sex single out 87%
+// of the US population).
+//
+// Quasi-identifiers are read from the entities found by Scan (birth dates,
+// ages, postcodes and cities of addresses, cities) and from the text
+// (gender words and titles, occupations, bare postcodes). Values of
+// different kinds within riskWindow bytes form a combination, whose
+// identifying bits are the sum of the estimates in quasiBits. The
+// combination's score is the probability that no one else in a population
+// of 80 million shares it, exp(-population / 2^bits). Offsets refer to the
+// normalized text, like those of Scan.
+func AssessRisk(text string, entities []Entity) Risk {
+	text = Normalize(text)
+	qis := quasiIdentifiers(text, entities)
+	risk := Risk{QuasiIdentifiers: qis}
+	risk.Combinations = riskCombinations(qis)
+	if len(risk.Combinations) > 0 {
+		risk.Score = risk.Combinations[0].Score
+		risk.Explanation = risk.Combinations[0].Explanation
+	} else if len(qis) > 0 {
+		risk.Explanation = "no combination of quasi-identifiers describes one person"
+	} else {
+		risk.Explanation = "no quasi-identifiers found"
+	}
+	switch {
+	case risk.Score >= 0.5:
+		risk.Level = RiskHigh
+	case risk.Score >= 0.1:
+		risk.Level = RiskMedium
+	default:
+		risk.Level = RiskLow
+	}
+	return risk
+}
+
+// quasiIdentifiers returns the quasi-identifiers in text, sorted by Start.
+func quasiIdentifiers(text string, entities []Entity) []QuasiIdentifier {
+	var qis []QuasiIdentifier
+	add := func(kind string, start, end int, typ string) {
+		qis = append(qis, QuasiIdentifier{Kind: kind, Start: start, End: end, Text: text[start:end], Type: typ})
+	}
+	for _, e := range entities {
+		switch e.Type {
+		case "DATE":
+			if birthContextRe.MatchString(text[max(0, e.Start-40):e.Start]) {
+				add(QuasiBirthDate, e.Start, e.End, e.Type)
+			}
+		case "AGE":
+			add(QuasiAge, e.Start, e.End, e.Type)
+		case "ADDRESS":
+			switch {
+			case e.Address == nil:
+			case e.Address.Postcode != "":
+				add(QuasiPostcode, e.Start, e.End, e.Type)
+			case e.Address.City != "":
+				add(QuasiCity, e.Start, e.End, e.Type)
+			}
+		case "LOCATION":
+			if e.Subtype != "city" {
+				continue
+			}
+			// A postcode directly before the city: "10115 Berlin".
+			offset := max(0, e.Start-8)
+			if loc := postcodeBeforeRe.FindStringIndex(text[offset:e.Start]); loc != nil {
+				postcode := strings.TrimRight(text[offset+loc[0]:e.Start], " \t")
+				add(QuasiPostcode, offset+loc[0], offset+loc[0]+len(postcode), QuasiIdentifierType)
+			}
+			add(QuasiCity, e.Start, e.End, e.Type)
+		}
+	}
+
+	covered := func(start, end int) bool {
+		for _, e := range entities {
+			if start < e.End && e.Start < end {
+				return true
+			}
+		}
+		for _, q := range qis {
+			if start < q.End && q.Start < end {
+				return true
+			}
+		}
+		return false
+	}
+	addText := func(kind string, start, end int) {
+		if !covered(start, end) {
+			add(kind, start, end, QuasiIdentifierType)
+		}
+	}
+	for _, loc := range postcodeContextRe.FindAllStringSubmatchIndex(text, -1) {
+		addText(QuasiPostcode, loc[2], loc[3])
+	}
+	for _, loc := range genderCodeRe.FindAllStringSubmatchIndex(text, -1) {
+		addText(QuasiGender, loc[2], loc[3])
+	}
+	for _, loc := range genderRe.FindAllStringIndex(text, -1) {
+		if isWholeWord(text, loc[0], loc[1]) {
+			addText(QuasiGender, loc[0], loc[1])
+		}
+	}
+	for _, loc := range occupationRe.FindAllStringIndex(text, -1) {
+		if isWholeWord(text, loc[0], loc[1]) {
+			addText(QuasiOccupation, loc[0], loc[1])
+		}
+	}
+	for _, loc := range occupationContextRe.FindAllStringSubmatchIndex(text, -1) {
+		addText(QuasiOccupation, loc[2], loc[3])
+	}
+
+	sort.Slice(qis, func(i, j int) bool { return qis[i].Start < qis[j].Start })
+	return qis
+}
+
+// isWholeWord reports whether text[start:end] is not part of a longer word.
+func isWholeWord(text string, start, end int) bool {
+	after, _ := utf8.DecodeRuneInString(text[end:])
+	return !isLetterBefore(text, start) && !unicode.IsLetter(after)
+}
+
+// riskCombinations returns the combinations of quasi-identifiers, highest
+// score first. Each one holds the first value of every kind within
+// riskWindow bytes of its first value; combinations contained in another
+// are dropped. A birth date makes an age redundant, a postcode the city.
+func riskCombinations(qis []QuasiIdentifier) []RiskCombination {
+	var sets [][]QuasiIdentifier
+	for i, first := range qis {
+		byKind := make(map[string]QuasiIdentifier)
+		for _, q := range qis[i:] {
+			if q.Start-first.Start > riskWindow {
+				break
+			}
+			if _, ok := byKind[q.Kind]; !ok {
+				byKind[q.Kind] = q
+			}
+		}
+		if _, ok := byKind[QuasiBirthDate]; ok {
+			delete(byKind, QuasiAge)
+		}
+		if _, ok := byKind[QuasiPostcode]; ok {
+			delete(byKind, QuasiCity)
+		}
+		if len(byKind) < 2 {
+			continue
+		}
+		var set []QuasiIdentifier
+		for _, kind := range quasiKinds {
+			if q, ok := byKind[kind]; ok {
+				set = append(set, q)
+			}
+		}
+		sets = append(sets, set)
+	}
+
+	var combinations []RiskCombination
+	for i, set := range sets {
+		contained := false
+		for j, other := range sets {
+			if i != j && containsAll(other, set) && (len(other) > len(set) || j < i) {
+				contained = true
+				break
+			}
+		}
+		if !contained {
+			combinations = append(combinations, newRiskCombination(set))
+		}
+	}
+	sort.SliceStable(combinations, func(i, j int) bool { return combinations[i].Score > combinations[j].Score })
+	return combinations
+}
+
+// containsAll reports whether every quasi-identifier of sub is in set.
+func containsAll(set, sub []QuasiIdentifier) bool {
+	for _, q := range sub {
+		if !slices.Contains(set, q) {
+			return false
+		}
+	}
+	return true
+}
+
+func newRiskCombination(set []QuasiIdentifier) RiskCombination {
+	c := RiskCombination{QuasiIdentifiers: set}
+	names := make([]string, len(set))
+	start, end := set[0].Start, set[0].End
+	for i, q := range set {
+		c.Kinds = append(c.Kinds, q.Kind)
+		c.Bits += quasiBits[q.Kind]
+		names[i] = quasiNames[q.Kind]
+		start, end = min(start, q.Start), max(end, q.End)
+	}
+	c.Bits = math.Round(c.Bits*10) / 10
+	c.Score = math.Round(math.Exp(-riskPopulation/math.Exp2(c.Bits))*100) / 100
+	c.Explanation = fmt.Sprintf("%s within %d characters carry about %.1f bits; in a population of 80 million the combination is unique with probability %.0f%%",
+		joinNames(names), end-start, c.Bits, c.Score*100)
+	return c
+}
+
+// joinNames joins names as "a, b and c".
+func joinNames(names []string) string {
+	if len(names) == 1 {
+		return names[0]
+	}
+	return strings.Join(names[:len(names)-1], ", ") + " and " + names[len(names)-1]
+}
@@ -0,0 +1,74 @@
+package scanner
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestAssessRisk_Sweeney(t *testing.T) {
+	text := "DOB: 1985-03-12, zip 90210, sex: F."
+	risk := AssessRisk(text, DefaultScanner(nil).Scan(text))
+
+	if len(risk.Combinations) != 1 {
+		t.Fatalf("got %d combinations, want 1: %+v", len(risk.Combinations), risk.Combinations)
+	}
+	c := risk.Combinations[0]
+	if want := []string{QuasiBirthDate, QuasiPostcode, QuasiGender}; !slices.Equal(c.Kinds, want) {
+		t.Errorf("Kinds = %v, want %v", c.Kinds, want)
+	}
+	if risk.Score < 0.8 || risk.Level != RiskHigh {
+		t.Errorf("Score = %g, Level = %q, want high", risk.Score, risk.Level)
+	}
+	if risk.Explanation == "" || risk.Explanation != c.Explanation {
+		t.Errorf("Explanation = %q", risk.Explanation)
+	}
+}
+
+func TestAssessRisk_QuasiIdentifiers(t *testing.T) {
+	text := "Patient, männlich, geboren am 12.03.1985, wohnhaft in 10115 Berlin, von Beruf Lehrer."
+	risk := AssessRisk(text, DefaultScanner(nil).Scan(text))
+
+	var got []string
+	for _, q := range risk.QuasiIdentifiers {
+		got = append(got, q.Kind+":"+q.Text)
+		if text[q.Start:q.End] != q.Text {
+			t.Errorf("%s: offsets %d-%d do not span %q", q.Kind, q.Start, q.End, q.Text)
+		}
+	}
+	want := []string{"gender:männlich", "birth_date:12.03.1985", "postcode:10115", "city:Berlin", "occupation:Lehrer"}
+	if !slices.Equal(got, want) {
+		t.Errorf("quasi-identifiers = %v, want %v", got, want)
+	}
+	// The postcode makes the city redundant.
+	if len(risk.Combinations) == 0 || slices.Contains(risk.Combinations[0].Kinds, QuasiCity) {
+		t.Errorf("Combinations = %+v", risk.Combinations)
+	}
+	if risk.Level != RiskHigh {
+		t.Errorf("Level = %q, want high", risk.Level)
+	}
+}
+
+func TestAssessRisk_Low(t *testing.T) {
+	cases := []string{
+		"Sehr geehrte Frau Müller, vielen Dank für Ihre Nachricht vom 12.03.2024.",
+		"A 45-year-old woman.",
+		"The meeting is on 12.03.2024 in Berlin.",
+	}
+	for _, text := range cases {
+		risk := AssessRisk(text, DefaultScanner(nil).Scan(text))
+		if risk.Level != RiskLow {
+			t.Errorf("%q: Level = %q (%g), want low: %s", text, risk.Level, risk.Score, risk.Explanation)
+		}
+	}
+}
+
+func TestAssessRisk_Window(t *testing.T) {
+	text := "geboren am 12.03.1985." + strings.Repeat(" ", riskWindow) + "PLZ 10115, männlich"
+	risk := AssessRisk(text, DefaultScanner(nil).Scan(text))
+	for _, c := range risk.Combinations {
+		if slices.Contains(c.Kinds, QuasiBirthDate) {
+			t.Errorf("birth date combined across %d bytes: %+v", riskWindow, c)
+		}
+	}
+}
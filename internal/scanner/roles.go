@@ -0,0 +1,80 @@
+package scanner
+
+import (
+	"regexp"
+	"slices"
+	"sync"
+)
+
+// --- Person roles ---
+
+// Roles recorded on PERSON entities introduced by a trigger word.
+const (
+	RolePatient    = "patient"     // Patient, Patientin, my patient
+	RoleClinician  = "clinician"   // Oberarzt, Ärztin, Dr. med., Nurse
+	RoleLegalParty = "legal_party" // Rechtsanwalt, Kläger, Zeuge, defendant
+	RoleFamily     = "family"      // Ehefrau, his wife, mein Freund
+	RoleBusiness   = "business"    // Geschäftsführer, Sachbearbeiter, Manager
+	RoleTitle      = "title"       // Herr, Frau, Dr., Monsieur
+)
+
+// Roles lists the person roles in order of specificity.
+var Roles = []string{RolePatient, RoleClinician, RoleLegalParty, RoleFamily, RoleBusiness, RoleTitle}
+
+// personTriggerRes are the personTriggers compiled to match a leading
+// trigger and the blanks after it.
+var personTriggerRes = sync.OnceValue(func() []*regexp.Regexp {
+	res := make([]*regexp.Regexp, len(personTriggers))
+	for i, t := range personTriggers {
+		res[i] = regexp.MustCompile(`(?i)^(?:` + t.pattern + `)(?:[ \t]+|$)`)
+	}
+	return res
+})
+
+// personTriggerRole returns the most specific role implied by the chain of
+// triggers matched by the person context pattern, e.g. clinician for
+// "Oberarzt Dr.". Triggers are tried in the same order as in the pattern.
+func personTriggerRole(triggers string) string {
+	role := ""
+	for rest := triggers; rest != ""; {
+		matched := false
+		for i, re := range personTriggerRes() {
+			if loc := re.FindStringIndex(rest); loc != nil {
+				role = moreSpecificRole(role, personTriggers[i].role)
+				rest = rest[loc[1]:]
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			break
+		}
+	}
+	return role
+}
+
+// moreSpecificRole returns whichever of a and b comes first in Roles.
+func moreSpecificRole(a, b string) string {
+	if a == "" || (b != "" && slices.Index(Roles, b) < slices.Index(Roles, a)) {
+		return b
+	}
+	return a
+}
+
+// propagateRoles gives every PERSON mention of an identity cluster the most
+// specific role recorded on any of them, so that "Frau Weber" and a later
+// "Weber" share the role of "Patientin Anna Weber".
+func propagateRoles(entities []Entity) []Entity {
+	best := make(map[int]string)
+	for _, e := range entities {
+		if e.Type == "PERSON" && e.Cluster != 0 {
+			best[e.Cluster] = moreSpecificRole(best[e.Cluster], e.Role)
+		}
+	}
+	for i, e := range entities {
+		if role := best[e.Cluster]; role != "" && e.Type == "PERSON" {
+			entities[i].Role = role
+		}
+	}
+	return entities
+}
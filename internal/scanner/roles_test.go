@@ -0,0 +1,60 @@
+package scanner
+
+import "testing"
+
+func TestPersonRole_FromTrigger(t *testing.T) {
+	s := DefaultScanner(nil)
+	cases := []struct {
+		input string
+		name  string
+		want  string
+	}{
+		{"Patientin Anna Weber wurde aufgenommen.", "Anna Weber", RolePatient},
+		{"Aufnahme durch Oberarzt Thomas Schmidt.", "Thomas Schmidt", RoleClinician},
+		{"Visite: Oberarzt Dr. Thomas Schmidt", "Thomas Schmidt", RoleClinician},
+		{"Vertreten durch Rechtsanwältin Julia Becker.", "Julia Becker", RoleLegalParty},
+		{"Vernommen wurde Zeuge Peter Wagner.", "Peter Wagner", RoleLegalParty},
+		{"The witness John Miller testified.", "John Miller", RoleLegalParty},
+		{"Kontakt: seine Frau Maria Huber", "Maria Huber", RoleFamily},
+		{"Geschäftsführer Klaus Richter unterschreibt.", "Klaus Richter", RoleBusiness},
+		{"Sehr geehrte Frau Sabine Koch,", "Sabine Koch", RoleTitle},
+	}
+	for _, tc := range cases {
+		t.Run(tc.input, func(t *testing.T) {
+			for _, e := range s.Scan(tc.input) {
+				if e.Type == "PERSON" && e.Text == tc.name {
+					if e.Role != tc.want {
+						t.Errorf("Role = %q, want %q", e.Role, tc.want)
+					}
+					return
+				}
+			}
+			t.Fatalf("PERSON %q not found", tc.name)
+		})
+	}
+}
+
+func TestPersonRole_SharedWithinCluster(t *testing.T) {
+	input := "Patientin Anna Weber wurde aufgenommen. Frau Weber klagt über Schmerzen."
+	var mentions int
+	for _, e := range DefaultScanner(nil).Scan(input) {
+		if e.Type != "PERSON" {
+			continue
+		}
+		mentions++
+		if e.Role != RolePatient {
+			t.Errorf("%q: Role = %q, want %q", e.Text, e.Role, RolePatient)
+		}
+	}
+	if mentions != 2 {
+		t.Errorf("want 2 PERSON mentions, got %d", mentions)
+	}
+}
+
+func TestPersonRole_NoTrigger(t *testing.T) {
+	for _, e := range DefaultScanner(nil).Scan("I told Thomas Schmidt about it.") {
+		if e.Role != "" {
+			t.Errorf("%q: unexpected Role %q", e.Text, e.Role)
+		}
+	}
+}
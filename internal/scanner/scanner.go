@@ -2,9 +2,8 @@ package scanner
 
 import (
 	"regexp"
+	"slices"
 	"sort"
-
-	"golang.org/x/text/unicode/norm"
 )
 
 // Scanner detects PII entities in text.
@@ -26,6 +25,12 @@ type RegexScanner struct {
 	// extractGroup specifies which submatch group to use as the entity text.
 	// 0 means the full match, 1+ means the corresponding capture group.
 	extractGroup int
+	// subtype is copied to every emitted entity's Subtype field.
+	subtype string
+	// roleGroup and classifyRole derive each entity's Role from the text of
+	// a capture group, e.g. the trigger word that preceded a name.
+	roleGroup    int
+	classifyRole func(string) string
 }
 
 // RegexScannerOption configures a RegexScanner.
@@ -47,6 +52,17 @@ func WithExtractGroup(group int) RegexScannerOption {
 	return func(rs *RegexScanner) { rs.extractGroup = group }
 }
 
+// WithSubtype sets the Subtype reported on every entity from this scanner.
+func WithSubtype(subtype string) RegexScannerOption {
+	return func(rs *RegexScanner) { rs.subtype = subtype }
+}
+
+// WithRole sets the Role of every entity to classify applied to the text
+// of the given capture group.
+func WithRole(group int, classify func(string) string) RegexScannerOption {
+	return func(rs *RegexScanner) { rs.roleGroup, rs.classifyRole = group, classify }
+}
+
 // NewRegexScanner creates a scanner from a compiled regex.
 func NewRegexScanner(re *regexp.Regexp, entityType string, score float64, opts ...RegexScannerOption) *RegexScanner {
 	rs := &RegexScanner{re: re, entityType: entityType, score: score}
@@ -58,7 +74,7 @@ func NewRegexScanner(re *regexp.Regexp, entityType string, score float64, opts .
 
 // Scan finds all matches in text and returns entities with byte offsets.
 func (rs *RegexScanner) Scan(text string) []Entity {
-	if rs.extractGroup > 0 {
+	if rs.extractGroup > 0 || rs.classifyRole != nil {
 		return rs.scanWithGroups(text)
 	}
 
@@ -79,6 +95,7 @@ func (rs *RegexScanner) Scan(text string) []Entity {
 			Text:     matched,
 			Score:    rs.score,
 			Detector: "regex",
+			Subtype:  rs.subtype,
 		})
 	}
 	return entities
@@ -101,6 +118,10 @@ func (rs *RegexScanner) scanWithGroups(text string) []Entity {
 		if rs.contextValidate != nil && !rs.contextValidate(text, start, end) {
 			continue
 		}
+		role := ""
+		if r := rs.roleGroup; rs.classifyRole != nil && r*2+1 < len(loc) && loc[r*2] >= 0 {
+			role = rs.classifyRole(text[loc[r*2]:loc[r*2+1]])
+		}
 		entities = append(entities, Entity{
 			Start:    start,
 			End:      end,
@@ -108,6 +129,8 @@ func (rs *RegexScanner) scanWithGroups(text string) []Entity {
 			Text:     matched,
 			Score:    rs.score,
 			Detector: "regex",
+			Subtype:  rs.subtype,
+			Role:     role,
 		})
 	}
 	return entities
@@ -115,8 +138,10 @@ func (rs *RegexScanner) scanWithGroups(text string) []Entity {
 
 // CompositeScanner runs multiple scanners and merges/deduplicates results.
 type CompositeScanner struct {
-	scanners  []Scanner
-	allowlist []*regexp.Regexp
+	scanners     []Scanner
+	allowlist    []*regexp.Regexp
+	transcript   bool
+	dropTestData bool
 }
 
 // NewCompositeScanner creates a scanner that runs all provided scanners.
@@ -125,22 +150,36 @@ func NewCompositeScanner(scanners []Scanner, allowlist []*regexp.Regexp) *Compos
 }
 
 // Scan runs all child scanners, merges results, deduplicates overlapping
-// entities (keeping the longer match), filters by allowlist, and sorts by Start.
+// entities (keeping the longer match), filters by allowlist, enriches the
+// rest, and sorts by Start. Offsets refer to the input as returned by
+// Normalize.
 func (cs *CompositeScanner) Scan(text string) []Entity {
-	// NFC normalize before scanning.
-	text = norm.NFC.String(text)
+	text = Normalize(text)
+	if canon := canonicalize(text, cs.transcript); canon != nil {
+		return renormalizeEmails(canon.restore(text, cs.scan(canon.text)))
+	}
+	return cs.scan(text)
+}
 
+// scan does the work of Scan on the canonicalized text; each step is
+// described on its helper.
+func (cs *CompositeScanner) scan(text string) []Entity {
 	var all []Entity
 	for _, s := range cs.scanners {
 		all = append(all, s.Scan(text)...)
 	}
 
-	// Sort by Start, then by length descending (longer match first).
+	// Sort by Start, then by length descending (longer match first), then by
+	// score so that identical spans keep the most confident detection.
 	sort.Slice(all, func(i, j int) bool {
 		if all[i].Start != all[j].Start {
 			return all[i].Start < all[j].Start
 		}
-		return (all[i].End - all[i].Start) > (all[j].End - all[j].Start)
+		li, lj := all[i].End-all[i].Start, all[j].End-all[j].Start
+		if li != lj {
+			return li > lj
+		}
+		return all[i].Score > all[j].Score
 	})
 
 	// Deduplicate: keep longer match when overlapping.
@@ -157,25 +196,59 @@ func (cs *CompositeScanner) Scan(text string) []Entity {
 		}
 	}
 
-	// Allowlist filter: drop entities matching any allowlist pattern.
-	if len(cs.allowlist) > 0 {
-		filtered := make([]Entity, 0, len(deduped))
-		for _, e := range deduped {
-			allowed := false
-			for _, al := range cs.allowlist {
-				if al.MatchString(e.Text) {
-					allowed = true
-					break
-				}
-			}
-			if !allowed {
-				filtered = append(filtered, e)
+	// Allowlist filter, then coreference so that allowlisted names are not
+	// resolved; the mentions found by coreference are filtered as well.
+	entities := cs.filterAllowlist(deduped)
+	entities = cs.filterAllowlist(resolveCoreferences(text, entities))
+	entities = markTestData(entities, cs.dropTestData)
+	entities = parseAddresses(mergeAddresses(text, entities))
+	entities = normalizeEmails(parseURLs(parseAmounts(entities)))
+	return propagateRoles(linkBankAccounts(text, clusterIdentities(text, entities)))
+}
+
+// TranscriptMode returns a copy of cs for speech-to-text transcripts.
+// Numbers and dates spoken as words ("zero one seven zero, one two three",
+// "vier fünf sechs", "the fifteenth of March") in English, German, French,
+// Spanish and Italian are converted to digits before scanning, so the phone,
+// card and date scanners and their validators apply. Entities span the
+// original words. Dates without a year are detected as well.
+func (cs *CompositeScanner) TranscriptMode() *CompositeScanner {
+	if cs.transcript {
+		return cs
+	}
+	c := *cs
+	c.scanners = append(slices.Clip(cs.scanners), transcriptDateScanners()...)
+	c.transcript = true
+	return &c
+}
+
+// DropTestData returns a copy of cs that drops well-known test values and
+// values from documentation ranges instead of flagging them as TestData.
+func (cs *CompositeScanner) DropTestData() *CompositeScanner {
+	c := *cs
+	c.dropTestData = true
+	return &c
+}
+
+// filterAllowlist drops entities matching any allowlist pattern.
+func (cs *CompositeScanner) filterAllowlist(entities []Entity) []Entity {
+	if len(cs.allowlist) == 0 {
+		return entities
+	}
+	filtered := make([]Entity, 0, len(entities))
+	for _, e := range entities {
+		allowed := false
+		for _, al := range cs.allowlist {
+			if al.MatchString(e.Text) {
+				allowed = true
+				break
 			}
 		}
-		return filtered
+		if !allowed {
+			filtered = append(filtered, e)
+		}
 	}
-
-	return deduped
+	return filtered
 }
 
 // DefaultScanner returns a CompositeScanner with all built-in patterns.
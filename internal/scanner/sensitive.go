@@ -0,0 +1,314 @@
+package scanner
+
+import (
+	"slices"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// --- SENSITIVE_CATEGORY ---
+
+// Subtypes of SENSITIVE_CATEGORY, the special categories of personal data
+// of GDPR Article 9.
+const (
+	SensitiveHealth            = "health"
+	SensitiveReligion          = "religion"
+	SensitiveEthnicity         = "ethnicity"
+	SensitiveSexualOrientation = "sexual_orientation"
+	SensitivePoliticalOpinion  = "political_opinion"
+	SensitiveTradeUnion        = "trade_union"
+	SensitiveBiometric         = "biometric"
+)
+
+const (
+	// minSensitiveStem is the shortest stem a "*" lexicon word may have.
+	minSensitiveStem = 4
+	// sensitiveNegationWindow and sensitiveContextWindow are how many words
+	// before a term are searched for a negation or a context cue;
+	// sensitiveFollowingWords how many after it.
+	sensitiveNegationWindow = 4
+	sensitiveContextWindow  = 3
+	sensitiveFollowingWords = 2
+)
+
+// sensitiveTerm is one lexicon entry: a word or phrase that reveals a
+// special category of data.
+type sensitiveTerm struct {
+	// words are lowercased unless exactCase is set; a trailing "*" matches
+	// any word starting with the rest.
+	words   []string
+	subtype string
+	// ambiguous marks terms that double as names or ordinary words
+	// ("Krebs", "gay", "Roma") and need a context cue.
+	ambiguous bool
+	// exactCase compares case-sensitively, for acronyms ("AIDS", "SPD").
+	exactCase bool
+}
+
+// matchWord reports whether the i-th word of t matches w.
+func (t *sensitiveTerm) matchWord(i int, w string) bool {
+	if !t.exactCase {
+		w = strings.ToLower(w)
+	}
+	if stem, ok := strings.CutSuffix(t.words[i], "*"); ok {
+		return strings.HasPrefix(w, stem)
+	}
+	return w == t.words[i]
+}
+
+// sensitiveLexicon indexes terms by their first word: exact words by the
+// lowercased word, stems by their first minSensitiveStem letters.
+type sensitiveLexicon struct {
+	byWord map[string][]*sensitiveTerm
+	byStem map[string][]*sensitiveTerm
+}
+
+var loadSensitiveLexicon = sync.OnceValue(func() *sensitiveLexicon {
+	lex := &sensitiveLexicon{
+		byWord: make(map[string][]*sensitiveTerm),
+		byStem: make(map[string][]*sensitiveTerm),
+	}
+	for _, line := range readGazetteer("sensitive_terms.tsv.gz") {
+		fields := strings.Split(line, "\t")
+		if len(fields) != 3 {
+			continue
+		}
+		for _, phrase := range strings.Split(fields[2], "|") {
+			t := &sensitiveTerm{
+				subtype:   fields[0],
+				ambiguous: strings.Contains(fields[1], "c"),
+				exactCase: strings.Contains(fields[1], "u"),
+			}
+			for _, w := range strings.Fields(phrase) {
+				if !t.exactCase {
+					w = strings.ToLower(w)
+				}
+				t.words = append(t.words, w)
+			}
+			first := t.words[0]
+			if stem, ok := strings.CutSuffix(first, "*"); ok {
+				if utf8.RuneCountInString(stem) < minSensitiveStem {
+					panic("scanner: sensitive term stem too short: " + phrase)
+				}
+				key := stemKey(strings.ToLower(stem))
+				lex.byStem[key] = append(lex.byStem[key], t)
+			} else {
+				key := strings.ToLower(first)
+				lex.byWord[key] = append(lex.byWord[key], t)
+			}
+		}
+	}
+	return lex
+})
+
+// stemKey returns the first minSensitiveStem runes of the lowercased word w.
+func stemKey(w string) string {
+	n := 0
+	for i := range w {
+		if n == minSensitiveStem {
+			return w[:i]
+		}
+		n++
+	}
+	return w
+}
+
+// candidates returns the terms whose first word may match w.
+func (lex *sensitiveLexicon) candidates(w string) []*sensitiveTerm {
+	lower := strings.ToLower(w)
+	return append(slices.Clip(lex.byWord[lower]), lex.byStem[stemKey(lower)]...)
+}
+
+// sensitiveNegations are words that negate a following term within the same
+// clause ("kein Diabetes", "not gay", "sans handicap").
+var sensitiveNegations = map[string]bool{
+	// EN
+	"no": true, "not": true, "never": true, "without": true, "denies": true, "denied": true, "nor": true, "negative": true,
+	// DE
+	"kein": true, "keine": true, "keinen": true, "keiner": true, "keinem": true, "keines": true,
+	"nicht": true, "ohne": true, "nie": true, "niemals": true, "verneint": true, "weder": true,
+	// FR
+	"pas": true, "sans": true, "aucun": true, "aucune": true, "jamais": true, "ni": true, "non": true,
+	// ES
+	"sin": true, "ningún": true, "ninguna": true, "ninguno": true, "nunca": true, "niega": true,
+	// IT
+	"senza": true, "nessun": true, "nessuna": true, "nessuno": true, "mai": true, "nega": true, "né": true,
+}
+
+// sensitivePostNegations negate a directly preceding term ("HIV negativ",
+// "Diabetes ausgeschlossen", "cancer ruled out").
+var sensitivePostNegations = map[string]bool{
+	"negative": true, "negativ": true, "négatif": true, "négative": true, "negativo": true, "negativa": true,
+	"ausgeschlossen": true, "excluded": true, "ruled": true, "exclu": true, "exclue": true, "escluso": true, "esclusa": true, "descartado": true, "descartada": true,
+}
+
+// sensitiveClauseBreaks are conjunctions that end the scope of a negation
+// ("kein Fieber, aber Diabetes").
+var sensitiveClauseBreaks = map[string]bool{
+	"but": true, "aber": true, "sondern": true, "jedoch": true, "mais": true, "pero": true, "sino": true, "ma": true, "però": true,
+}
+
+// sensitiveCues are words that mark an ambiguous term as a statement about
+// a person: linking verbs, diagnoses, membership and belief.
+var sensitiveCues = map[string]bool{
+	// EN
+	"is": true, "was": true, "are": true, "am": true, "been": true, "has": true, "had": true, "have": true, "with": true,
+	"suffers": true, "suffering": true, "diagnosed": true, "member": true, "supporter": true, "voted": true, "votes": true,
+	"voter": true, "identifies": true, "openly": true, "practising": true, "practicing": true, "devout": true, "converted": true,
+	// DE
+	"ist": true, "war": true, "sind": true, "bin": true, "hat": true, "hatte": true, "haben": true, "mit": true,
+	"leidet": true, "diagnostiziert": true, "mitglied": true, "anhänger": true, "anhängerin": true, "wählt": true,
+	"wählte": true, "wähler": true, "wählerin": true, "bekennend": true, "bekennender": true, "bekennende": true,
+	"gläubig": true, "gläubiger": true, "gläubige": true, "praktizierend": true, "praktizierender": true, "praktizierende": true, "konvertiert": true,
+	// FR
+	"est": true, "était": true, "avec": true, "souffre": true, "atteint": true, "atteinte": true, "diagnostiqué": true,
+	"membre": true, "militant": true, "adhérent": true, "vote": true, "électeur": true, "pratiquant": true, "croyant": true,
+	// ES
+	"es": true, "está": true, "era": true, "tiene": true, "con": true, "padece": true, "sufre": true, "diagnosticado": true,
+	"miembro": true, "afiliado": true, "militante": true, "vota": true, "votante": true, "practicante": true, "creyente": true,
+	// IT
+	"è": true, "ha": true, "soffre": true, "affetto": true, "affetta": true, "diagnosticato": true, "membro": true,
+	"iscritto": true, "tesserato": true, "elettore": true, "praticante": true, "credente": true,
+}
+
+// sensitivePostCues mark an ambiguous term when they directly follow it
+// ("an Krebs erkrankt").
+var sensitivePostCues = map[string]bool{
+	"erkrankt": true, "diagnostiziert": true, "diagnosed": true, "diagnostiqué": true, "diagnosticado": true, "diagnosticato": true,
+}
+
+// SensitiveCategoryScanner finds statements that reveal special categories
+// of personal data (GDPR Art. 9): health conditions, religion, ethnicity,
+// sexual orientation, political opinion, trade union membership and
+// biometric data, from an embedded multilingual lexicon (EN, DE, FR, ES, IT).
+//
+// Negated mentions ("kein Diabetes", "HIV negative", "not gay") are not
+// reported. Terms that double as names or ordinary words ("Krebs", "Roma",
+// "SPD") need a context cue such as "hat", "is" or "Mitglied".
+type SensitiveCategoryScanner struct {
+	lex *sensitiveLexicon
+}
+
+// NewSensitiveCategoryScanner creates a SENSITIVE_CATEGORY scanner backed by
+// the embedded lexicon.
+func NewSensitiveCategoryScanner() *SensitiveCategoryScanner {
+	return &SensitiveCategoryScanner{lex: loadSensitiveLexicon()}
+}
+
+func sensitiveCategoryScanners() []Scanner {
+	return []Scanner{NewSensitiveCategoryScanner()}
+}
+
+// Scan finds all non-negated special-category terms in text.
+func (ss *SensitiveCategoryScanner) Scan(text string) []Entity {
+	words := splitWords(text)
+	for i := range words {
+		words[i].text = strings.ReplaceAll(words[i].text, "’", "'")
+	}
+	var entities []Entity
+	for i := 0; i < len(words); i++ {
+		t, n := ss.longestMatch(text, words, i)
+		if t == nil {
+			continue
+		}
+		start, end := words[i].start, words[i+n-1].end
+		if embeddedInToken(text, start, end) || sensitiveNegated(text, words, i, i+n) {
+			continue
+		}
+		score := 0.85
+		if t.ambiguous {
+			if !sensitiveContext(text, words, i, i+n) {
+				continue
+			}
+			score = 0.75
+		}
+		entities = append(entities, Entity{
+			Start:    start,
+			End:      end,
+			Type:     "SENSITIVE_CATEGORY",
+			Text:     text[start:end],
+			Score:    score,
+			Detector: "gazetteer",
+			Subtype:  t.subtype,
+		})
+		i += n - 1
+	}
+	return entities
+}
+
+// longestMatch returns the longest term starting at words[i] and its length
+// in words.
+func (ss *SensitiveCategoryScanner) longestMatch(text string, words []word, i int) (*sensitiveTerm, int) {
+	var best *sensitiveTerm
+	for _, t := range ss.lex.candidates(words[i].text) {
+		n := len(t.words)
+		if i+n > len(words) || (best != nil && n <= len(best.words)) {
+			continue
+		}
+		if !wordsAdjacent(text, words[i:i+n]) {
+			continue
+		}
+		matched := true
+		for k := 0; k < n && matched; k++ {
+			matched = t.matchWord(k, words[i+k].text)
+		}
+		if matched {
+			best = t
+		}
+	}
+	if best == nil {
+		return nil, 0
+	}
+	return best, len(best.words)
+}
+
+// clauseBreak reports whether the text between two words ends a clause.
+func clauseBreak(text string, prev, next word) bool {
+	return strings.ContainsAny(text[prev.end:next.start], ".;:!?\n")
+}
+
+// sensitiveNegated reports whether the term words[from:to] is negated by a
+// preceding word in the same clause or a directly following one.
+func sensitiveNegated(text string, words []word, from, to int) bool {
+	for k := from - 1; k >= max(0, from-sensitiveNegationWindow); k-- {
+		w := strings.ToLower(words[k].text)
+		if clauseBreak(text, words[k], words[k+1]) || sensitiveClauseBreaks[w] {
+			break
+		}
+		if sensitiveNegations[w] {
+			return true
+		}
+	}
+	for k := to; k < min(len(words), to+sensitiveFollowingWords); k++ {
+		if clauseBreak(text, words[k-1], words[k]) {
+			break
+		}
+		if sensitivePostNegations[strings.ToLower(words[k].text)] {
+			return true
+		}
+	}
+	return false
+}
+
+// sensitiveContext reports whether a context cue precedes the term
+// words[from:to] in the same clause or a post-cue directly follows it.
+func sensitiveContext(text string, words []word, from, to int) bool {
+	for k := from - 1; k >= max(0, from-sensitiveContextWindow); k-- {
+		if clauseBreak(text, words[k], words[k+1]) {
+			break
+		}
+		if sensitiveCues[strings.ToLower(words[k].text)] {
+			return true
+		}
+	}
+	for k := to; k < min(len(words), to+sensitiveFollowingWords); k++ {
+		if clauseBreak(text, words[k-1], words[k]) {
+			break
+		}
+		if sensitivePostCues[strings.ToLower(words[k].text)] {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,83 @@
+package scanner
+
+import "testing"
+
+func TestSensitiveCategory_Detected(t *testing.T) {
+	s := DefaultScanner(nil)
+	cases := []struct {
+		input   string
+		want    string
+		subtype string
+	}{
+		{"She is HIV positive.", "HIV positive", SensitiveHealth},
+		{"Der Patient leidet an Diabetes mellitus Typ 2.", "Diabetes", SensitiveHealth},
+		{"Er hat Krebs.", "Krebs", SensitiveHealth},
+		{"Elle est enceinte de trois mois.", "enceinte", SensitiveHealth},
+		{"Er ist Mitglied der evangelischen Kirche.", "evangelischen Kirche", SensitiveReligion},
+		{"Sie ist praktizierende Muslimin.", "Muslimin", SensitiveReligion},
+		{"He is gay.", "gay", SensitiveSexualOrientation},
+		{"Herr Weber ist Gewerkschaftsmitglied.", "Gewerkschaftsmitglied", SensitiveTradeUnion},
+		{"Sie ist Mitglied der SPD.", "SPD", SensitivePoliticalOpinion},
+		{"Familie mit Migrationshintergrund", "Migrationshintergrund", SensitiveEthnicity},
+		{"Zugang per Fingerabdruck und Gesichtserkennung", "Fingerabdruck", SensitiveBiometric},
+		{"Il est séropositif depuis 2015.", "séropositif", SensitiveHealth},
+		{"È iscritto al sindacato.", "sindacato", SensitiveTradeUnion},
+	}
+	for _, tc := range cases {
+		t.Run(tc.input, func(t *testing.T) {
+			for _, e := range s.Scan(tc.input) {
+				if e.Type == "SENSITIVE_CATEGORY" && e.Text == tc.want {
+					if e.Subtype != tc.subtype {
+						t.Errorf("Subtype = %q, want %q", e.Subtype, tc.subtype)
+					}
+					return
+				}
+			}
+			t.Fatalf("SENSITIVE_CATEGORY %q not found in %q", tc.want, tc.input)
+		})
+	}
+}
+
+func TestSensitiveCategory_Negated(t *testing.T) {
+	s := DefaultScanner(nil)
+	cases := []string{
+		"Kein Diabetes, keine Hypertonie.",
+		"Kein Hinweis auf Diabetes.",
+		"HIV negativ getestet.",
+		"He is not gay.",
+		"Il n'a pas de diabète.",
+		"Sin diabetes.",
+		"Diabetes ausgeschlossen.",
+		"Patient denies depression.",
+	}
+	for _, input := range cases {
+		if n := countEntitiesOfType(s.Scan(input), "SENSITIVE_CATEGORY"); n != 0 {
+			t.Errorf("negated mention flagged in %q", input)
+		}
+	}
+}
+
+func TestSensitiveCategory_NegationScope(t *testing.T) {
+	input := "Kein Fieber, aber Diabetes seit 2010."
+	if !hasEntityWithText(DefaultScanner(nil).Scan(input), "SENSITIVE_CATEGORY", "Diabetes") {
+		t.Errorf("negation leaked past the clause in %q", input)
+	}
+}
+
+func TestSensitiveCategory_AmbiguousNeedsContext(t *testing.T) {
+	s := DefaultScanner(nil)
+	cases := []string{
+		"Herr Krebs ruft morgen an.",
+		"Wir fahren nach Roma.",
+		"The SSH host key fingerprint changed.",
+		"Christian und Anna kommen auch.",
+		"Die SPD stellt den Bürgermeister.",
+	}
+	for _, input := range cases {
+		for _, e := range s.Scan(input) {
+			if e.Type == "SENSITIVE_CATEGORY" {
+				t.Errorf("false positive in %q: %v", input, e)
+			}
+		}
+	}
+}
@@ -0,0 +1,806 @@
+package scanner
+
+import (
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// --- Spoken numbers (transcript mode) ---
+
+// spokenKind classifies a number word by how it combines with its neighbours.
+type spokenKind int
+
+const (
+	spokenDigit    spokenKind = iota // 0–9
+	spokenTeen                       // 10–19
+	spokenTens                       // 20, 30, … 90
+	spokenHundred                    // multiplies the preceding number by 100
+	spokenThousand                   // multiplies the preceding number by 1000
+	spokenCompound                   // any other value: "twenty-three", "vierundzwanzig", "doscientos"
+)
+
+// spokenCardinals maps number words to their value, per language.
+var spokenCardinals = []struct {
+	lang  string
+	words map[string]int
+}{
+	{"en", map[string]int{
+		"zero": 0, "oh": 0, "one": 1, "two": 2, "three": 3, "four": 4, "five": 5,
+		"six": 6, "seven": 7, "eight": 8, "nine": 9, "ten": 10, "eleven": 11,
+		"twelve": 12, "thirteen": 13, "fourteen": 14, "fifteen": 15, "sixteen": 16,
+		"seventeen": 17, "eighteen": 18, "nineteen": 19, "twenty": 20, "thirty": 30,
+		"forty": 40, "fifty": 50, "sixty": 60, "seventy": 70, "eighty": 80,
+		"ninety": 90, "hundred": 100, "thousand": 1000,
+	}},
+	{"de", map[string]int{
+		"null": 0, "eins": 1, "zwei": 2, "zwo": 2, "drei": 3, "vier": 4, "fünf": 5,
+		"sechs": 6, "sieben": 7, "acht": 8, "neun": 9, "zehn": 10, "elf": 11,
+		"zwölf": 12, "dreizehn": 13, "vierzehn": 14, "fünfzehn": 15, "sechzehn": 16,
+		"siebzehn": 17, "achtzehn": 18, "neunzehn": 19, "zwanzig": 20, "dreißig": 30,
+		"dreissig": 30, "vierzig": 40, "fünfzig": 50, "sechzig": 60, "siebzig": 70,
+		"achtzig": 80, "neunzig": 90, "hundert": 100, "tausend": 1000,
+	}},
+	{"fr", map[string]int{
+		"zéro": 0, "un": 1, "deux": 2, "trois": 3, "quatre": 4, "cinq": 5, "six": 6,
+		"sept": 7, "huit": 8, "neuf": 9, "dix": 10, "onze": 11, "douze": 12,
+		"treize": 13, "quatorze": 14, "quinze": 15, "seize": 16, "vingt": 20,
+		"vingts": 20, "trente": 30, "quarante": 40, "cinquante": 50, "soixante": 60,
+		"cent": 100, "cents": 100, "mille": 1000,
+	}},
+	{"es", map[string]int{
+		"cero": 0, "uno": 1, "un": 1, "dos": 2, "tres": 3, "cuatro": 4, "cinco": 5,
+		"seis": 6, "siete": 7, "ocho": 8, "nueve": 9, "diez": 10, "once": 11,
+		"doce": 12, "trece": 13, "catorce": 14, "quince": 15, "dieciséis": 16,
+		"dieciseis": 16, "diecisiete": 17, "dieciocho": 18, "diecinueve": 19,
+		"veinte": 20, "veintiuno": 21, "veintiún": 21, "veintidós": 22, "veintidos": 22,
+		"veintitrés": 23, "veintitres": 23, "veinticuatro": 24, "veinticinco": 25,
+		"veintiséis": 26, "veintiseis": 26, "veintisiete": 27, "veintiocho": 28,
+		"veintinueve": 29, "treinta": 30, "cuarenta": 40, "cincuenta": 50,
+		"sesenta": 60, "setenta": 70, "ochenta": 80, "noventa": 90, "cien": 100,
+		"ciento": 100, "doscientos": 200, "trescientos": 300, "cuatrocientos": 400,
+		"quinientos": 500, "seiscientos": 600, "setecientos": 700,
+		"ochocientos": 800, "novecientos": 900, "mil": 1000,
+	}},
+	{"it", map[string]int{
+		"zero": 0, "uno": 1, "due": 2, "tre": 3, "quattro": 4, "cinque": 5, "sei": 6,
+		"sette": 7, "otto": 8, "nove": 9, "dieci": 10, "undici": 11, "dodici": 12,
+		"tredici": 13, "quattordici": 14, "quindici": 15, "sedici": 16,
+		"diciassette": 17, "diciotto": 18, "diciannove": 19, "venti": 20,
+		"trenta": 30, "quaranta": 40, "cinquanta": 50, "sessanta": 60,
+		"settanta": 70, "ottanta": 80, "novanta": 90, "cento": 100, "mille": 1000,
+		"mila": 1000,
+	}},
+}
+
+// spokenOrdinals maps ordinal words used for days of the month. German
+// ordinals are derived from the cardinals (see germanOrdinal).
+var spokenOrdinals = map[string]int{
+	"first": 1, "second": 2, "third": 3, "fourth": 4, "fifth": 5, "sixth": 6,
+	"seventh": 7, "eighth": 8, "ninth": 9, "tenth": 10, "eleventh": 11,
+	"twelfth": 12, "thirteenth": 13, "fourteenth": 14, "fifteenth": 15,
+	"sixteenth": 16, "seventeenth": 17, "eighteenth": 18, "nineteenth": 19,
+	"twentieth": 20, "thirtieth": 30,
+	"premier": 1, "première": 1, "primero": 1, "primer": 1, "primo": 1,
+}
+
+// spokenMultipliers repeat the following digit: "double five" is "55".
+var spokenMultipliers = map[string]int{
+	"double": 2, "triple": 3, "doppel": 2, "doble": 2, "doppio": 2, "triplo": 3,
+}
+
+// spokenConjunctions may join the parts of one number: "one hundred and
+// five", "vingt et un", "treinta y dos".
+var spokenConjunctions = map[string]bool{
+	"and": true, "und": true, "et": true, "y": true, "e": true,
+}
+
+// spokenPlus introduces an international dialling code.
+var spokenPlus = map[string]bool{"plus": true, "più": true}
+
+// spokenMonth is a month name recognised next to a spoken day.
+type spokenMonth struct {
+	month int
+	// capital requires an uppercase initial (English and German), so that
+	// "may" and "march" as ordinary words are not taken for months.
+	capital bool
+	en      bool
+}
+
+var spokenMonths = map[string]spokenMonth{
+	// English (April, August, September and November also German)
+	"january": {1, true, true}, "february": {2, true, true}, "march": {3, true, true},
+	"april": {4, true, true}, "may": {5, true, true}, "june": {6, true, true},
+	"july": {7, true, true}, "august": {8, true, true}, "september": {9, true, true},
+	"october": {10, true, true}, "november": {11, true, true}, "december": {12, true, true},
+	// German
+	"januar": {1, true, false}, "jänner": {1, true, false}, "februar": {2, true, false},
+	"märz": {3, true, false}, "juni": {6, true, false}, "juli": {7, true, false},
+	"oktober": {10, true, false}, "dezember": {12, true, false},
+	// French ("mai" also German)
+	"janvier": {1, false, false}, "février": {2, false, false}, "mars": {3, false, false},
+	"avril": {4, false, false}, "mai": {5, false, false}, "juin": {6, false, false},
+	"juillet": {7, false, false}, "août": {8, false, false}, "septembre": {9, false, false},
+	"octobre": {10, false, false}, "novembre": {11, false, false}, "décembre": {12, false, false},
+	// Spanish ("marzo" and "agosto" also Italian)
+	"enero": {1, false, false}, "febrero": {2, false, false}, "marzo": {3, false, false},
+	"abril": {4, false, false}, "mayo": {5, false, false}, "junio": {6, false, false},
+	"julio": {7, false, false}, "agosto": {8, false, false}, "septiembre": {9, false, false},
+	"setiembre": {9, false, false}, "octubre": {10, false, false}, "noviembre": {11, false, false},
+	"diciembre": {12, false, false},
+	// Italian
+	"gennaio": {1, false, false}, "febbraio": {2, false, false}, "aprile": {4, false, false},
+	"maggio": {5, false, false}, "giugno": {6, false, false}, "luglio": {7, false, false},
+	"settembre": {9, false, false}, "ottobre": {10, false, false}, "dicembre": {12, false, false},
+}
+
+// minSpokenDigits is the number of digits a converted run must have. Shorter
+// runs ("two kids", "Otto") are left alone: they cannot form a phone or
+// card number, and converting them would only disturb the other scanners.
+const minSpokenDigits = 4
+
+// spokenPart is one number word, or a hyphenated compound of several.
+type spokenPart struct {
+	value   int
+	kind    spokenKind
+	slot    int // values below slot may be added to this one: 10 after "twenty"
+	lang    string
+	ordinal bool
+}
+
+// spokenToken is a word or digit sequence in the text.
+type spokenToken struct {
+	start, end int
+	text       string // as written
+	lower      string
+	digits     bool
+}
+
+// spokenGroup is one number within a run: "twenty three", "double five",
+// "0170" or "fifteenth".
+type spokenGroup struct {
+	start, end int
+	out        string // the digits that replace the group
+	lang       string
+	ordinal    bool
+	literal    bool // written in digits and kept as is
+	pause      bool // preceded by a comma or dash
+}
+
+// spokenRun is a sequence of number groups separated by blanks or pauses.
+type spokenRun struct {
+	groups      []spokenGroup
+	first, next int // index of the first token and of the token after the run
+}
+
+// spokenNumbers converts spoken numbers and dates to digits:
+//
+//   - runs of number words and digits with at least minSpokenDigits digits,
+//     "zero one seven zero, one two three" → "0170 123", "vier fünf sechs
+//     sieben" → "4567", "double five" → "55", "nineteen ninety" → "1990";
+//   - a day next to a month name, "the fifteenth of March" → "the 15 March",
+//     "am fünfzehnten März" → "am 15. März", "le quinze mars" → "le 15 mars".
+//
+// Blanks between groups are dropped and pauses become a single space, so
+// the digit patterns see numbers grouped the way they were dictated.
+func (c *canonicalText) spokenNumbers() *canonicalText {
+	text := c.text
+	toks := spokenTokens(text)
+	var edits []textEdit
+	for i := 0; i < len(toks); {
+		run := parseSpokenRun(text, toks, i)
+		if run == nil {
+			i++
+			continue
+		}
+		if date, ok := spokenDate(text, toks, run); ok {
+			edits = append(edits, date...)
+		} else if run.isNumber() {
+			edits = append(edits, run.edits(text)...)
+		}
+		i = run.next
+	}
+	return c.apply(edits)
+}
+
+// spokenTokens splits text into words (with inner hyphens, "vingt-deux")
+// and ASCII digit sequences.
+func spokenTokens(text string) []spokenToken {
+	var toks []spokenToken
+	start, digits := -1, false
+	flush := func(end int) {
+		if start >= 0 {
+			t := text[start:end]
+			toks = append(toks, spokenToken{start: start, end: end, text: t, lower: strings.ToLower(t), digits: digits})
+			start = -1
+		}
+	}
+	for i := 0; i < len(text); {
+		r, size := utf8.DecodeRuneInString(text[i:])
+		isDigit := r >= '0' && r <= '9'
+		isLetter := unicode.IsLetter(r) || unicode.Is(unicode.Mn, r)
+		switch {
+		case start >= 0 && ((digits && isDigit) || (!digits && isLetter)):
+		case start >= 0 && !digits && r == '-' && i+size < len(text):
+			if next, _ := utf8.DecodeRuneInString(text[i+size:]); !unicode.IsLetter(next) {
+				flush(i)
+			}
+		case isDigit || isLetter:
+			flush(i)
+			start, digits = i, isDigit
+		default:
+			flush(i)
+		}
+		i += size
+	}
+	flush(len(text))
+	return toks
+}
+
+// parseSpokenRun parses the run of number groups starting at toks[i], or
+// returns nil if toks[i] does not start one.
+func parseSpokenRun(text string, toks []spokenToken, i int) *spokenRun {
+	run := &spokenRun{first: i}
+	var b spokenBuilder
+	plus := -1 // start of a leading "plus"
+	pause := false
+
+	closeGroup := func() {
+		if g, ok := b.close(); ok {
+			run.add(g, pause, plus)
+			pause, plus = false, -1
+		}
+	}
+
+	j := i
+loop:
+	for ; j < len(toks); j++ {
+		t := toks[j]
+		if j > i {
+			p, ok := spokenGap(text[toks[j-1].end:t.start])
+			if !ok {
+				break
+			}
+			if p {
+				closeGroup()
+				pause = true
+			}
+		}
+
+		if t.digits {
+			closeGroup()
+			run.add(spokenGroup{start: t.start, end: t.end, out: t.text, literal: true}, pause, plus)
+			pause, plus = false, -1
+			continue
+		}
+
+		part, ok := lookupSpokenPart(t.lower)
+		switch {
+		case ok && t.lower == "oh" && !b.active && len(run.groups) == 0 && !spokenFollows(text, toks, j):
+			// "oh" is only a zero inside a number.
+			break loop
+		case ok:
+			if !b.add(part) {
+				closeGroup()
+				b.add(part)
+			}
+			b.extend(t, part)
+			if part.ordinal {
+				closeGroup()
+				j++
+				break loop
+			}
+		case spokenPlus[t.lower] && j == i && spokenFollows(text, toks, j):
+			plus = t.start
+		case spokenMultipliers[t.lower] > 0 && spokenFollows(text, toks, j):
+			next := toks[j+1]
+			digit, _ := lookupSpokenPart(next.lower)
+			if digit.kind != spokenDigit || digit.ordinal {
+				break loop
+			}
+			closeGroup()
+			out := strings.Repeat(strconv.Itoa(digit.value), spokenMultipliers[t.lower])
+			run.add(spokenGroup{start: t.start, end: next.end, out: out}, pause, plus)
+			pause, plus = false, -1
+			j++
+		case spokenConjunctions[t.lower] && b.active && spokenFollows(text, toks, j):
+			next, _ := lookupSpokenPart(toks[j+1].lower)
+			if next.value <= 0 || next.value >= b.slot {
+				break loop
+			}
+		default:
+			break loop
+		}
+	}
+	closeGroup()
+
+	if len(run.groups) == 0 {
+		return nil
+	}
+	run.next = j
+	return run
+}
+
+// spokenFollows reports whether toks[j] is followed by a number word
+// separated only by blanks.
+func spokenFollows(text string, toks []spokenToken, j int) bool {
+	if j+1 >= len(toks) {
+		return false
+	}
+	if p, ok := spokenGap(text[toks[j].end:toks[j+1].start]); !ok || p {
+		return false
+	}
+	_, ok := lookupSpokenPart(toks[j+1].lower)
+	return ok
+}
+
+// spokenGap classifies the separator between two tokens of a run: blanks
+// join them, a comma or dash is a pause, anything else ends the run.
+func spokenGap(gap string) (pause, ok bool) {
+	trimmed := strings.Trim(gap, " \t")
+	switch trimmed {
+	case "":
+		return false, gap != ""
+	case ",", "-", "–":
+		return true, true
+	}
+	return false, false
+}
+
+// add appends a finished group to the run.
+func (r *spokenRun) add(g spokenGroup, pause bool, plus int) {
+	g.pause = pause && len(r.groups) > 0
+	if plus >= 0 && len(r.groups) == 0 {
+		g.start, g.out, g.literal = plus, "+"+g.out, false
+	}
+	r.groups = append(r.groups, g)
+}
+
+// isNumber reports whether the run should be converted as a number: it
+// contains a number word, no ordinal and enough digits.
+func (r *spokenRun) isNumber() bool {
+	words, digits := false, 0
+	for _, g := range r.groups {
+		if g.ordinal {
+			return false
+		}
+		words = words || !g.literal
+		digits += len(strings.Trim(g.out, "+"))
+	}
+	return words && digits >= minSpokenDigits
+}
+
+// edits returns the replacements converting the run to digits.
+func (r *spokenRun) edits(text string) []textEdit {
+	var edits []textEdit
+	for k, g := range r.groups {
+		if k > 0 {
+			prev := r.groups[k-1]
+			switch {
+			case g.pause:
+				edits = append(edits, textEdit{start: prev.end, end: g.start, out: " "})
+			case !prev.literal || !g.literal:
+				edits = append(edits, textEdit{start: prev.end, end: g.start})
+			}
+		}
+		if !g.literal {
+			edits = append(edits, textEdit{start: g.start, end: g.end, out: g.out})
+		}
+	}
+	return edits
+}
+
+// spokenDate converts a spoken day next to a month name: "fifteenth of
+// March", "March fifteenth", "fünfzehnten März", "quince de marzo".
+func spokenDate(text string, toks []spokenToken, run *spokenRun) ([]textEdit, bool) {
+	if len(run.groups) != 1 || run.groups[0].literal {
+		return nil, false
+	}
+	g := run.groups[0]
+	day, err := strconv.Atoi(g.out)
+	if err != nil || day < 1 || day > 31 {
+		return nil, false
+	}
+	out := g.out
+	if g.lang == "de" {
+		out += "."
+	}
+	edits := []textEdit{{start: g.start, end: g.end, out: out}}
+
+	// Month before the day: "March fifteenth".
+	if p := run.first - 1; p >= 0 {
+		if m, ok := lookupSpokenMonth(toks[p]); ok && m.en && spokenBlank(text, toks[p].end, g.start) {
+			return edits, true
+		}
+	}
+	// Month after the day, possibly joined by "of" or "de".
+	n := run.next
+	if n >= len(toks) || !spokenBlank(text, g.end, toks[n].start) {
+		return nil, false
+	}
+	if toks[n].lower == "of" || toks[n].lower == "de" {
+		if n+1 >= len(toks) || !spokenBlank(text, toks[n].end, toks[n+1].start) {
+			return nil, false
+		}
+		if toks[n].lower == "of" {
+			edits = append(edits, textEdit{start: g.end, end: toks[n].end})
+		}
+		n++
+	}
+	if _, ok := lookupSpokenMonth(toks[n]); !ok {
+		return nil, false
+	}
+	return edits, true
+}
+
+func spokenBlank(text string, from, to int) bool {
+	return to > from && strings.Trim(text[from:to], " \t") == ""
+}
+
+func lookupSpokenMonth(t spokenToken) (spokenMonth, bool) {
+	m, ok := spokenMonths[t.lower]
+	if !ok || (m.capital && !isUpperInitial(t.text)) {
+		return spokenMonth{}, false
+	}
+	return m, true
+}
+
+// lookupSpokenPart looks up a lowercased number word. Hyphenated compounds
+// ("twenty-three", "quatre-vingt-dix", "twenty-first") must combine into a
+// single number.
+func lookupSpokenPart(w string) (spokenPart, bool) {
+	if !strings.Contains(w, "-") {
+		return lookupSpokenWord(w)
+	}
+	var b spokenBuilder
+	var last spokenPart
+	for _, sub := range strings.Split(w, "-") {
+		if spokenConjunctions[sub] {
+			continue // "vingt-et-un"
+		}
+		p, ok := lookupSpokenWord(sub)
+		if !ok || last.ordinal || !b.add(p) {
+			return spokenPart{}, false
+		}
+		last = p
+	}
+	p := newSpokenPart(b.value, last.lang)
+	p.ordinal = last.ordinal
+	return p, true
+}
+
+// lookupSpokenWord looks up a single lowercased number word.
+func lookupSpokenWord(w string) (spokenPart, bool) {
+	for _, l := range spokenCardinals {
+		if v, ok := l.words[w]; ok {
+			p := newSpokenPart(v, l.lang)
+			if w == "dix" || w == "soixante" {
+				// "soixante-dix" (70), "soixante-douze" (72), "dix-sept" (17).
+				p.slot = max(p.slot, 10)
+				if w == "soixante" {
+					p.slot = 20
+				}
+			}
+			return p, true
+		}
+	}
+	if v, ok := spokenOrdinals[w]; ok {
+		p := newSpokenPart(v, "")
+		p.ordinal = true
+		return p, true
+	}
+	if v, ok := germanNumber(w); ok {
+		return newSpokenPart(v, "de"), true
+	}
+	if v, ok := germanOrdinal(w); ok {
+		p := newSpokenPart(v, "de")
+		p.ordinal = true
+		return p, true
+	}
+	if v, ok := italianNumber(w); ok {
+		return newSpokenPart(v, "it"), true
+	}
+	return spokenPart{}, false
+}
+
+// newSpokenPart classifies a value and derives the values it can absorb.
+func newSpokenPart(v int, lang string) spokenPart {
+	p := spokenPart{value: v, lang: lang}
+	switch {
+	case v < 10:
+		p.kind, p.slot = spokenDigit, 1
+		if v == 0 {
+			p.slot = 0
+		}
+	case v < 20:
+		p.kind, p.slot = spokenTeen, 1
+	case v == 100:
+		p.kind, p.slot = spokenHundred, 100
+	case v == 1000:
+		p.kind, p.slot = spokenThousand, 1000
+	case v < 100 && v%10 == 0:
+		p.kind, p.slot = spokenTens, 10
+	default:
+		p.kind = spokenCompound
+		switch {
+		case v%1000 == 0:
+			p.slot = 1000
+		case v%100 == 0:
+			p.slot = 100
+		case v%10 == 0:
+			p.slot = 10
+		default:
+			p.slot = 1
+		}
+	}
+	return p
+}
+
+// germanNumber parses German compound numbers written as one word:
+// "vierundzwanzig", "hundertdrei", "zweitausendsechsundzwanzig".
+func germanNumber(w string) (int, bool) {
+	base := spokenCardinals[1].words
+	if v, ok := base[w]; ok {
+		return v, true
+	}
+	units := func(s string) (int, bool) {
+		if s == "ein" {
+			return 1, true
+		}
+		v, ok := base[s]
+		return v, ok && v >= 1 && v <= 9
+	}
+	for _, m := range []struct {
+		word string
+		mult int
+	}{{"tausend", 1000}, {"hundert", 100}} {
+		left, right, ok := strings.Cut(w, m.word)
+		if !ok {
+			continue
+		}
+		l, r := 1, 0
+		if left != "" {
+			var okL bool
+			if l, okL = units(left); !okL {
+				l, okL = germanNumber(left) // "neunzehnhundert"
+			}
+			if !okL || l < 1 || l >= m.mult {
+				return 0, false
+			}
+		}
+		if right != "" {
+			var okR bool
+			if r, okR = germanNumber(right); !okR || r >= m.mult {
+				return 0, false
+			}
+		}
+		return l*m.mult + r, true
+	}
+	if u, t, ok := strings.Cut(w, "und"); ok {
+		uv, okU := units(u)
+		tv, okT := base[t]
+		if okU && okT && tv >= 20 && tv <= 90 && tv%10 == 0 {
+			return tv + uv, true
+		}
+	}
+	return 0, false
+}
+
+// germanOrdinal parses inflected German ordinals: "erste", "dritten",
+// "fünfzehnter", "einundzwanzigsten".
+func germanOrdinal(w string) (int, bool) {
+	for _, suffix := range []string{"en", "er", "es", "em", "e"} {
+		stem, ok := strings.CutSuffix(w, suffix)
+		if !ok {
+			continue
+		}
+		switch stem {
+		case "erst":
+			return 1, true
+		case "dritt":
+			return 3, true
+		case "siebt":
+			return 7, true
+		case "acht":
+			return 8, true
+		}
+		if s, ok := strings.CutSuffix(stem, "st"); ok {
+			if v, ok := germanNumber(s); ok && v >= 20 && v <= 31 {
+				return v, true
+			}
+		}
+		if s, ok := strings.CutSuffix(stem, "t"); ok {
+			if v, ok := germanNumber(s); ok && v >= 2 && v <= 19 {
+				return v, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// italianTens are the Italian tens with their elided forms before "uno"
+// and "otto" ("ventuno", "trentotto").
+var italianTens = []struct {
+	word  string
+	value int
+}{
+	{"venti", 20}, {"vent", 20}, {"trenta", 30}, {"trent", 30},
+	{"quaranta", 40}, {"quarant", 40}, {"cinquanta", 50}, {"cinquant", 50},
+	{"sessanta", 60}, {"sessant", 60}, {"settanta", 70}, {"settant", 70},
+	{"ottanta", 80}, {"ottant", 80}, {"novanta", 90}, {"novant", 90},
+}
+
+// italianNumber parses Italian compound numbers written as one word:
+// "ventitré", "trentotto", "centoventi", "duemilaventisei".
+func italianNumber(w string) (int, bool) {
+	base := spokenCardinals[4].words
+	if v, ok := base[w]; ok {
+		return v, true
+	}
+	if w == "tré" {
+		return 3, true
+	}
+	if left, right, ok := strings.Cut(w, "mila"); ok {
+		return italianMultiple(left, right, 1000, 2)
+	}
+	if right, ok := strings.CutPrefix(w, "mille"); ok {
+		return italianMultiple("", right, 1000, 1)
+	}
+	if left, right, ok := strings.Cut(w, "cento"); ok {
+		return italianMultiple(left, right, 100, 1)
+	}
+	for _, t := range italianTens {
+		rest, ok := strings.CutPrefix(w, t.word)
+		if !ok || rest == "" {
+			continue
+		}
+		if v, ok := italianNumber(rest); ok && v >= 1 && v <= 9 {
+			return t.value + v, true
+		}
+	}
+	return 0, false
+}
+
+// italianMultiple combines "<left>cento<right>" and "<left>mila<right>".
+func italianMultiple(left, right string, mult, minLeft int) (int, bool) {
+	l, r := 1, 0
+	if left != "" {
+		var ok bool
+		if l, ok = italianNumber(left); !ok || l < minLeft || l >= mult {
+			return 0, false
+		}
+	}
+	if right != "" {
+		var ok bool
+		if r, ok = italianNumber(right); !ok || r >= mult {
+			return 0, false
+		}
+	}
+	return l*mult + r, true
+}
+
+// spokenBuilder accumulates the words of one number group.
+type spokenBuilder struct {
+	active    bool
+	value     int
+	slot      int // values below slot may still be added
+	thousands bool
+	last      spokenKind
+	g         spokenGroup
+}
+
+// add adds a word to the group and reports whether it belongs to it;
+// otherwise the caller closes the group and starts a new one. "twenty"
+// takes a following digit, "hundred" multiplies, "four one" are two groups.
+func (b *spokenBuilder) add(p spokenPart) bool {
+	if !b.active {
+		b.active = true
+		b.value, b.slot, b.last = p.value, p.slot, p.kind
+		b.thousands = p.value >= 1000
+		return true
+	}
+	switch {
+	case p.ordinal && p.value >= b.slot:
+		return false
+	case p.kind == spokenHundred:
+		rem := b.value % 1000
+		if rem < 1 || rem > 99 || b.last == spokenHundred {
+			return false
+		}
+		b.value += rem * 99
+		b.slot = 100
+	case p.kind == spokenThousand:
+		if b.thousands || b.value < 1 || b.value > 999 {
+			return false
+		}
+		b.value *= 1000
+		b.slot = 1000
+		b.thousands = true
+	case p.value == 20 && p.lang == "fr" && b.last == spokenDigit && b.value%100 == 4:
+		b.value += 76 // quatre-vingt
+		b.slot = 20
+	case p.value > 0 && p.value < b.slot:
+		b.value += p.value
+		b.slot = p.slot
+	default:
+		return false
+	}
+	b.last = p.kind
+	return true
+}
+
+// extend records that token t, holding p, is part of the group.
+func (b *spokenBuilder) extend(t spokenToken, p spokenPart) {
+	if b.g.end == 0 {
+		b.g.start = t.start
+	}
+	b.g.end = t.end
+	b.g.ordinal = b.g.ordinal || p.ordinal
+	if p.lang == "de" || b.g.lang == "" {
+		b.g.lang = p.lang
+	}
+}
+
+// close finishes the current group, if any.
+func (b *spokenBuilder) close() (spokenGroup, bool) {
+	if !b.active {
+		return spokenGroup{}, false
+	}
+	g := b.g
+	g.out = strconv.Itoa(b.value)
+	*b = spokenBuilder{}
+	return g, true
+}
+
+// --- DATE (transcript mode) ---
+
+// transcriptDateScanners detect a day and month without a year, as spoken
+// dates usually are: "15 March", "15. März", "15 de marzo", "March 15".
+// They only run in transcript mode, after spoken days have been converted
+// to digits.
+func transcriptDateScanners() []Scanner {
+	var names []string
+	for name, m := range spokenMonths {
+		title := strings.ToUpper(name[:1]) + name[1:]
+		if !m.capital {
+			names = append(names, name)
+		}
+		names = append(names, title)
+	}
+	// Longest first, so that "junio" is tried before "juni".
+	slices.SortFunc(names, func(a, b string) int {
+		if len(a) != len(b) {
+			return len(b) - len(a)
+		}
+		return strings.Compare(a, b)
+	})
+	months := `(?:` + strings.Join(names, "|") + `)`
+	day := `(?:[1-9]|[12]\d|3[01])`
+
+	dayMonth := `\b` + day + `\.?[ \t]+(?:de[ \t]+)?` + months + `\b`
+	monthDay := `\b` + months + `[ \t]+` + day + `\b`
+
+	return []Scanner{
+		NewRegexScanner(regexp.MustCompile(dayMonth), "DATE", 0.75, WithValidator(validDayOfMonth)),
+		NewRegexScanner(regexp.MustCompile(monthDay), "DATE", 0.75, WithValidator(validDayOfMonth)),
+	}
+}
+
+// daysInMonth allows 29 February, as the year is unknown.
+var daysInMonth = [13]int{0, 31, 29, 31, 30, 31, 30, 31, 31, 30, 31, 30, 31}
+
+// validDayOfMonth rejects "31 April" and "30 February".
+func validDayOfMonth(s string) bool {
+	day, month := 0, 0
+	for _, f := range strings.FieldsFunc(s, func(r rune) bool { return r == ' ' || r == '\t' || r == '.' }) {
+		if d, err := strconv.Atoi(f); err == nil {
+			day = d
+		} else if m, ok := spokenMonths[strings.ToLower(f)]; ok {
+			month = m.month
+		}
+	}
+	return month > 0 && day >= 1 && day <= daysInMonth[month]
+}
@@ -0,0 +1,109 @@
+package scanner
+
+import "testing"
+
+func TestTranscriptMode_SpokenNumbers(t *testing.T) {
+	s := DefaultScanner(nil).TranscriptMode()
+	cases := []struct {
+		name  string
+		input string
+		typ   string
+		want  string
+	}{
+		{"EN phone with pause", "my number is zero one seven zero, one two three four five six seven", "PHONE", "zero one seven zero, one two three four five six seven"},
+		{"EN double and oh", "call me on oh two oh, seven nine four six, double five double oh", "PHONE", "oh two oh, seven nine four six, double five double oh"},
+		{"EN international", "plus four nine one seven zero one two three four five six seven", "PHONE", "plus four nine one seven zero one two three four five six seven"},
+		{"DE phone", "meine Nummer ist null eins sieben null eins zwei drei vier fünf sechs sieben", "PHONE", "null eins sieben null eins zwei drei vier fünf sechs sieben"},
+		{"FR phone in pairs", "mon numéro est zéro six, douze, trente-quatre, cinquante-six, soixante-dix-huit", "PHONE", "zéro six, douze, trente-quatre, cinquante-six, soixante-dix-huit"},
+		{"ES phone", "mi número es cero seis uno dos tres cuatro cinco seis siete", "PHONE", "cero seis uno dos tres cuatro cinco seis siete"},
+		{"IT phone", "il mio numero è più tre nove tre tre tre, uno due tre quattro cinque sei sette", "PHONE", "più tre nove tre tre tre, uno due tre quattro cinque sei sette"},
+		{"EN card", "card four one one one one one one one one one one one one one one one", "CREDIT_CARD", "four one one one one one one one one one one one one one one one"},
+		{"mixed digits and words", "the card is 4111 one one one one, 1111 1111", "CREDIT_CARD", "4111 one one one one, 1111 1111"},
+		{"EN date with year", "born on the fifteenth of March nineteen ninety", "DATE", "fifteenth of March nineteen ninety"},
+		{"EN date, month first", "we meet on March fifteenth", "DATE", "March fifteenth"},
+		{"DE date", "am fünfzehnten März zweitausendsechsundzwanzig", "DATE", "fünfzehnten März zweitausendsechsundzwanzig"},
+		{"FR date", "le quinze mars deux mille vingt-six", "DATE", "quinze mars deux mille vingt-six"},
+		{"ES date", "el quince de marzo de dos mil veintiséis", "DATE", "quince de marzo de dos mil veintiséis"},
+		{"IT date without year", "il primo marzo", "DATE", "primo marzo"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			entities := s.Scan(tc.input)
+			if !hasEntityWithText(entities, tc.typ, tc.want) {
+				t.Fatalf("%s %q not found in %q, got %v", tc.typ, tc.want, tc.input, entities)
+			}
+			for _, e := range entities {
+				if tc.input[e.Start:e.End] != e.Text {
+					t.Errorf("offsets [%d:%d] do not point at %q", e.Start, e.End, e.Text)
+				}
+			}
+		})
+	}
+}
+
+func TestTranscriptMode_Validators(t *testing.T) {
+	s := DefaultScanner(nil).TranscriptMode()
+	cases := []struct {
+		name  string
+		input string
+		typ   string
+	}{
+		{"card failing Luhn", "card four one one one one one one one one one one one one one one two", "CREDIT_CARD"},
+		{"impossible date", "on the thirtieth of February", "DATE"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if n := countEntitiesOfType(s.Scan(tc.input), tc.typ); n != 0 {
+				t.Errorf("want no %s in %q, got %d", tc.typ, tc.input, n)
+			}
+		})
+	}
+}
+
+func TestTranscriptMode_LeavesOrdinaryWordsAlone(t *testing.T) {
+	s := DefaultScanner(nil).TranscriptMode()
+	cases := []string{
+		"I have two kids and three dogs.",
+		"Otto and Anna met once in May.",
+		"Wait a second, we may march on.",
+		"Sei sicuro? Tre giorni fa.",
+	}
+	for _, input := range cases {
+		if entities := s.Scan(input); len(entities) > 0 {
+			t.Errorf("false positive in %q: got %v", input, entities)
+		}
+	}
+}
+
+func TestTranscriptMode_OffByDefault(t *testing.T) {
+	input := "my number is zero one seven zero, one two three four five six seven"
+	if n := countEntitiesOfType(DefaultScanner(nil).Scan(input), "PHONE"); n != 0 {
+		t.Errorf("spoken numbers detected outside transcript mode: %d", n)
+	}
+}
+
+func TestSpokenNumberParsing(t *testing.T) {
+	cases := []struct {
+		word string
+		want int
+	}{
+		{"twenty-three", 23},
+		{"quatre-vingt-dix-sept", 97},
+		{"vingt-et-un", 21},
+		{"vierundzwanzig", 24},
+		{"neunzehnhundertneunzig", 1990},
+		{"zweitausendsechsundzwanzig", 2026},
+		{"ventitré", 23},
+		{"trentotto", 38},
+		{"duemilaventisei", 2026},
+		{"veintiséis", 26},
+		{"einundzwanzigsten", 21},
+		{"dritte", 3},
+	}
+	for _, tc := range cases {
+		p, ok := lookupSpokenPart(tc.word)
+		if !ok || p.value != tc.want {
+			t.Errorf("lookupSpokenPart(%q) = %d, %v; want %d", tc.word, p.value, ok, tc.want)
+		}
+	}
+}
@@ -0,0 +1,211 @@
+package scanner
+
+import (
+	"math"
+	"net"
+	"net/netip"
+	"net/url"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// --- Test data ---
+
+// testDataPenalty scales the score of entities recognised as test data.
+const testDataPenalty = 0.5
+
+// testValues is the embedded catalog of well-known test and specimen values,
+// keyed by entity type ("*" for any type) and then by normalized value.
+var testValues = sync.OnceValue(func() map[string]map[string]bool {
+	catalog := make(map[string]map[string]bool)
+	for _, line := range readGazetteer("test_values.tsv.gz") {
+		value, typ, ok := strings.Cut(line, "\t")
+		if !ok {
+			continue
+		}
+		if catalog[typ] == nil {
+			catalog[typ] = make(map[string]bool)
+		}
+		catalog[typ][normalizeTestValue(value)] = true
+	}
+	return catalog
+})
+
+// normalizeTestValue lowercases v and drops everything but letters, digits
+// and "@", so that "4111 1111 1111 1111" and "4111-1111-1111-1111" compare equal.
+func normalizeTestValue(v string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r) || r == '@':
+			return unicode.ToLower(r)
+		}
+		return -1
+	}, v)
+}
+
+// markTestData flags entities that are well-known test values or fall into
+// ranges reserved for documentation (example.com, TEST-NET addresses,
+// 555-01XX numbers) and lowers their score. With drop set they are removed
+// instead.
+func markTestData(entities []Entity, drop bool) []Entity {
+	kept := entities[:0]
+	for _, e := range entities {
+		if isTestData(e) {
+			if drop {
+				continue
+			}
+			e.TestData = true
+			e.Score = math.Round(e.Score*testDataPenalty*100) / 100
+		}
+		kept = append(kept, e)
+	}
+	return kept
+}
+
+// isTestData reports whether e is a catalogued test value or lies in a
+// reserved range.
+func isTestData(e Entity) bool {
+	text := e.Text
+	if e.Type == "PERSON" {
+		text = personName(e)
+	}
+	norm := normalizeTestValue(text)
+	catalog := testValues()
+	if catalog[e.Type][norm] || catalog["*"][norm] {
+		return true
+	}
+
+	switch e.Type {
+	case "EMAIL":
+		if _, domain, ok := strings.Cut(e.Text, "@"); ok {
+			return reservedDomain(domain)
+		}
+	case "URL":
+		if u, err := url.Parse(e.Text); err == nil {
+			return reservedDomain(u.Hostname())
+		}
+	case "IP_ADDRESS":
+		return reservedIP(e.Text)
+	case "PHONE":
+		return reservedPhone(asciiDigits(e.Text))
+	case "MAC_ADDRESS":
+		// RFC 7042 documentation range 00-00-5E-00-53-00 … FF.
+		return strings.HasPrefix(norm, "00005e0053")
+	case "SSN":
+		// 987-65-4320 … 4329 are reserved for advertising.
+		return len(norm) == 9 && strings.HasPrefix(norm, "98765432")
+	case "DEVICE_ID":
+		return strings.Trim(norm, "0") == ""
+	case "PERSON":
+		// "Herr Mustermann", "Erika Musterfrau"
+		words := strings.Fields(strings.ToLower(text))
+		last := strings.TrimRight(words[len(words)-1], ".,")
+		return last == "mustermann" || last == "musterfrau"
+	}
+	return false
+}
+
+// reservedDomain reports whether domain is reserved for documentation and
+// testing by RFC 2606 and RFC 6761.
+func reservedDomain(domain string) bool {
+	d := strings.TrimSuffix(strings.ToLower(domain), ".")
+	for _, reserved := range []string{"example.com", "example.net", "example.org"} {
+		if d == reserved || strings.HasSuffix(d, "."+reserved) {
+			return true
+		}
+	}
+	for _, tld := range []string{"example", "test", "invalid", "localhost"} {
+		if d == tld || strings.HasSuffix(d, "."+tld) {
+			return true
+		}
+	}
+	return false
+}
+
+// reservedNetworks are the documentation address blocks of RFC 5737,
+// RFC 3849 and RFC 5771 (MCAST-TEST-NET).
+var reservedNetworks = []netip.Prefix{
+	netip.MustParsePrefix("192.0.2.0/24"),
+	netip.MustParsePrefix("198.51.100.0/24"),
+	netip.MustParsePrefix("203.0.113.0/24"),
+	netip.MustParsePrefix("233.252.0.0/24"),
+	netip.MustParsePrefix("2001:db8::/32"),
+}
+
+func reservedIP(s string) bool {
+	ip := net.ParseIP(s)
+	if ip == nil {
+		return false
+	}
+	addr, ok := netip.AddrFromSlice(ip)
+	if !ok {
+		return false
+	}
+	addr = addr.Unmap()
+	for _, p := range reservedNetworks {
+		if p.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// reservedPhonePrefixes are UK numbers Ofcom reserves for drama and
+// documentation, in national format.
+var reservedPhonePrefixes = []string{
+	"07700900", // mobile
+	"02079460", // London
+	"01134960", // Leeds
+	"01144960", // Sheffield
+	"01154960", // Nottingham
+	"01164960", // Leicester
+	"01174960", // Bristol
+	"01184960", // Reading
+	"01214960", // Birmingham
+	"01314960", // Edinburgh
+	"01414960", // Glasgow
+	"01514960", // Liverpool
+	"01614960", // Manchester
+	"01914980", // Tyneside
+	"02890180", // Belfast
+	"02920180", // Cardiff
+	"08081570", // freephone
+	"09098790", // premium rate
+}
+
+// reservedPhone reports whether the digits of a phone number are a North
+// American 555-0100 … 555-0199 fictional number or an Ofcom drama number.
+func reservedPhone(digits string) bool {
+	digits = strings.TrimPrefix(digits, "00")
+	switch {
+	case len(digits) == 7:
+		return strings.HasPrefix(digits, "55501")
+	case len(digits) == 11 && digits[0] == '1':
+		digits = digits[1:]
+		fallthrough
+	case len(digits) == 10 && digits[0] != '0':
+		if digits[3:8] == "55501" {
+			return true
+		}
+	}
+	national := digits
+	if rest, ok := strings.CutPrefix(digits, "44"); ok {
+		national = "0" + strings.TrimPrefix(rest, "0")
+	}
+	for _, p := range reservedPhonePrefixes {
+		if strings.HasPrefix(national, p) {
+			return true
+		}
+	}
+	return false
+}
+
+func asciiDigits(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return r
+		}
+		return -1
+	}, s)
+}
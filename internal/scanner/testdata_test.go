@@ -0,0 +1,74 @@
+package scanner
+
+import "testing"
+
+func TestTestData_Flagged(t *testing.T) {
+	s := DefaultScanner(nil)
+	cases := []struct {
+		name  string
+		input string
+		typ   string
+		want  string
+	}{
+		{"test card", "card 4111 1111 1111 1111", "CREDIT_CARD", "4111 1111 1111 1111"},
+		{"sample IBAN", "IBAN DE89 3704 0044 0532 0130 00", "IBAN", "DE89 3704 0044 0532 0130 00"},
+		{"advertising SSN", "SSN 078-05-1120", "SSN", "078-05-1120"},
+		{"placeholder name", "Sehr geehrter Herr Max Mustermann", "PERSON", "Max Mustermann"},
+		{"specimen ID card", "Ausweis T22000129", "ID_NUMBER", "T22000129"},
+		{"example.com email", "Contact john.doe@example.com", "EMAIL", "john.doe@example.com"},
+		{".test domain", "mail foo@mail.test", "EMAIL", "foo@mail.test"},
+		{"example.org URL", "see http://www.example.org/path", "URL", "http://www.example.org/path"},
+		{"TEST-NET-1", "host 192.0.2.10", "IP_ADDRESS", "192.0.2.10"},
+		{"TEST-NET-3", "host 203.0.113.5", "IP_ADDRESS", "203.0.113.5"},
+		{"555 number", "Call (555) 555-0100", "PHONE", "(555) 555-0100"},
+		{"Ofcom drama number", "Tel +44 20 7946 0123", "PHONE", "+44 20 7946 0123"},
+		{"documentation MAC", "MAC 00:00:5E:00:53:01", "MAC_ADDRESS", "00:00:5E:00:53:01"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var found *Entity
+			for _, e := range s.Scan(tc.input) {
+				if e.Type == tc.typ && e.Text == tc.want {
+					found = &e
+				}
+			}
+			if found == nil {
+				t.Fatalf("%s %q not found in %q", tc.typ, tc.want, tc.input)
+			}
+			if !found.TestData {
+				t.Errorf("TestData not set on %v", *found)
+			}
+			if found.Score > 0.5 {
+				t.Errorf("score %.2f not lowered", found.Score)
+			}
+		})
+	}
+}
+
+func TestTestData_RealValuesNotFlagged(t *testing.T) {
+	s := DefaultScanner(nil)
+	cases := []string{
+		"card 4532 0151 1283 0366",
+		"IBAN DE44 5001 0517 5407 3249 31",
+		"Sehr geehrter Herr Thomas Schmidt",
+		"Contact thomas@firma.de",
+		"host 192.168.1.10",
+		"Call (212) 736-5000 today",
+		"Tel +44 20 7946 1234",
+	}
+	for _, input := range cases {
+		for _, e := range s.Scan(input) {
+			if e.TestData {
+				t.Errorf("real value flagged as test data in %q: %v", input, e)
+			}
+		}
+	}
+}
+
+func TestTestData_Drop(t *testing.T) {
+	s := DefaultScanner(nil).DropTestData()
+	entities := s.Scan("Max Mustermann, 4111 1111 1111 1111, thomas@firma.de")
+	if len(entities) != 1 || entities[0].Type != "EMAIL" {
+		t.Errorf("want only the real email, got %v", entities)
+	}
+}
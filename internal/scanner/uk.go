@@ -0,0 +1,115 @@
+package scanner
+
+import (
+	"regexp"
+	"strings"
+)
+
+// --- SSN / ID_NUMBER: United Kingdom ---
+
+// ukScanners detects UK identifiers: driving licence, passport, Companies
+// House and VAT numbers and Scottish CHI numbers. NINO and NHS numbers live
+// with the SSN and MEDICAL scanners, sort codes with BANK_ACCOUNT.
+func ukScanners() []Scanner {
+	return []Scanner{
+		// UK: DVLA driving licence: MORGA753116SM9IJ (surname, DOB, initials)
+		NewRegexScanner(
+			regexp.MustCompile(`\b[A-Z][A-Z9]{4} ?\d[0156]\d[0-3]\d\d ?[A-Z][A-Z9]\d[A-Z0-9]{2}\b`),
+			"ID_NUMBER", 0.90,
+			WithValidator(validateUKDrivingLicence),
+			WithSubtype("drivers_license"),
+		),
+		// UK: passport (context-triggered): 9 digits, newer ones 2 letters + 7 digits
+		NewRegexScanner(
+			regexp.MustCompile(`(?i:\b(?:UK|GB|British|United\s+Kingdom|HM)\s+passport(?:\s+(?:No\.?|Number))?)[:\s#]+(\d{9}|[A-Z]{2}\d{7})\b`),
+			"ID_NUMBER", 0.95,
+			WithExtractGroup(1),
+			WithSubtype("passport"),
+		),
+		// UK: Companies House number (context-triggered): 01234567, SC123456
+		NewRegexScanner(
+			regexp.MustCompile(`(?i:\bCompany\s+(?:Registration\s+)?(?:No\.?|Number)|\bRegistered\s+(?:in\s+England(?:\s+(?:and|&)\s+Wales)?\s+)?(?:No\.?|Number)|\bCRN\b)[:\s#]+((?:\d{2}|SC|NI|OC|SO|NC|LP|SL|NL|FC|SF|NF|GE|IP|SP|IC|SI|NP|NV|RC|SR|NR|NO|R0)\d{6})\b`),
+			"ID_NUMBER", 0.90,
+			WithExtractGroup(1),
+			WithSubtype("companies_house"),
+		),
+		// UK: VAT registration number: GB 980 7806 84, optional branch suffix
+		NewRegexScanner(
+			regexp.MustCompile(`\bGB ?\d{3} ?\d{4} ?\d{2}(?: ?\d{3})?\b`),
+			"ID_NUMBER", 0.90,
+			WithValidator(validateGBVAT),
+			WithSubtype("vat"),
+		),
+		// UK: government departments and health authorities: GBGD001, GBHA599
+		NewRegexScanner(
+			regexp.MustCompile(`\bGB(?:GD[0-4]|HA[5-9])\d{2}\b`),
+			"ID_NUMBER", 0.85,
+			WithSubtype("vat"),
+		),
+		// Scotland: Community Health Index number (context-triggered): DDMMYY + 4
+		NewRegexScanner(
+			regexp.MustCompile(`(?i:\bCHI(?:[ \t]+(?:No\.?|Number|#))?)[:\s]+(\d{6}[ \-]?\d{4})\b`),
+			"MEDICAL", 0.95,
+			WithExtractGroup(1),
+			WithValidator(validateCHI),
+			WithSubtype("chi"),
+		),
+	}
+}
+
+// invalidNINOPrefixes are the NINO prefixes HMRC never allocates. The
+// single letters D, F, I, Q, U, V (and O in second place) are already
+// excluded by the pattern.
+var invalidNINOPrefixes = map[string]bool{
+	"BG": true, "GB": true, "KN": true, "NK": true, "NT": true, "TN": true, "ZZ": true,
+}
+
+// validateNINO rejects National Insurance numbers with a prefix HMRC does
+// not allocate.
+func validateNINO(s string) bool {
+	return !invalidNINOPrefixes[s[:2]]
+}
+
+// validateUKDrivingLicence checks the fields a DVLA licence number encodes:
+// the surname (padded with 9s at the end only), the birth month (plus 50 for
+// women) and day. The pattern already restricts the initials.
+func validateUKDrivingLicence(s string) bool {
+	s = strings.ReplaceAll(s, " ", "")
+	if len(s) != 16 {
+		return false
+	}
+	surname := strings.TrimRight(s[:5], "9")
+	if strings.Contains(surname, "9") {
+		return false
+	}
+	month := int(s[6]-'0')%5*10 + int(s[7]-'0')
+	day := int(s[8]-'0')*10 + int(s[9]-'0')
+	// The year is only known up to the century; 2000 is a leap year.
+	year := 2000 + int(s[5]-'0')*10 + int(s[10]-'0')
+	return validYMD(year, month, day)
+}
+
+// validateGBVAT checks the mod-97 check digits of a UK VAT number: the first
+// seven digits weighted 8 down to 2 plus the two-digit check must be
+// divisible by 97, or, for numbers issued since 2010, 55 more than that.
+func validateGBVAT(s string) bool {
+	d := digitValues(s)
+	if len(d) != 9 && len(d) != 12 {
+		return false
+	}
+	sum := d[7]*10 + d[8]
+	for i := range 7 {
+		sum += d[i] * (8 - i)
+	}
+	return sum%97 == 0 || (sum+55)%97 == 0
+}
+
+// validateCHI checks a Scottish CHI number: a birth date (DDMMYY) followed by
+// four digits, with the same modulus 11 check digit as NHS numbers.
+func validateCHI(s string) bool {
+	d := digitValues(s)
+	if len(d) != 10 || !validYMD(2000, d[2]*10+d[3], d[0]*10+d[1]) {
+		return false
+	}
+	return validateNHS(s)
+}
@@ -0,0 +1,61 @@
+package scanner
+
+import "testing"
+
+func TestUK_Detected(t *testing.T) {
+	s := DefaultScanner(nil)
+	cases := []struct {
+		name    string
+		input   string
+		typ     string
+		want    string
+		subtype string
+	}{
+		{"NINO", "NI number AB 12 34 56 C", "SSN", "AB 12 34 56 C", "nino"},
+		{"NHS", "NHS No: 943 476 5919", "MEDICAL", "943 476 5919", "nhs"},
+		{"Health and Care Number", "Health and Care Number: 943-476-5919", "MEDICAL", "943-476-5919", "nhs"},
+		{"CHI", "CHI: 0101701233", "MEDICAL", "0101701233", "chi"},
+		{"driving licence", "Licence MORGA753116SM9IJ", "ID_NUMBER", "MORGA753116SM9IJ", "drivers_license"},
+		{"driving licence short surname", "Licence LI999602114A99AB", "ID_NUMBER", "LI999602114A99AB", "drivers_license"},
+		{"passport", "British passport number 925076473", "ID_NUMBER", "925076473", "passport"},
+		{"Companies House", "Registered in England and Wales No. 01234567", "ID_NUMBER", "01234567", "companies_house"},
+		{"Companies House Scotland", "Company No: SC123456", "ID_NUMBER", "SC123456", "companies_house"},
+		{"VAT", "VAT Reg GB 980 7806 84", "ID_NUMBER", "GB 980 7806 84", "vat"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			for _, e := range s.Scan(tc.input) {
+				if e.Type == tc.typ && e.Text == tc.want {
+					if e.Subtype != tc.subtype {
+						t.Errorf("Subtype = %q, want %q", e.Subtype, tc.subtype)
+					}
+					return
+				}
+			}
+			t.Fatalf("%s %q not found in %q, got %v", tc.typ, tc.want, tc.input, s.Scan(tc.input))
+		})
+	}
+}
+
+func TestUK_Rejected(t *testing.T) {
+	s := DefaultScanner(nil)
+	cases := []struct {
+		input string
+		typ   string
+	}{
+		{"NI number GB 12 34 56 C", "SSN"},
+		{"NI number TN 12 34 56 C", "SSN"},
+		{"NHS No: 943 476 5918", "MEDICAL"},
+		{"CHI: 3201701233", "MEDICAL"},
+		{"Licence MORGA713116SM9IJ", "ID_NUMBER"},
+		{"Licence MO9GA753116SM9IJ", "ID_NUMBER"},
+		{"VAT Reg GB 980 7806 85", "ID_NUMBER"},
+	}
+	for _, tc := range cases {
+		for _, e := range s.Scan(tc.input) {
+			if e.Type == tc.typ {
+				t.Errorf("invalid identifier detected in %q: %v", tc.input, e)
+			}
+		}
+	}
+}
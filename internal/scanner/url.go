@@ -0,0 +1,176 @@
+package scanner
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// --- URL: components ---
+
+// urlEmailRe matches a decoded URL component that is an email address.
+var urlEmailRe = regexp.MustCompile(`^[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}$`)
+
+// urlParamTypes maps query and fragment keys to the entity type of their
+// value. Keys are compared lowercased with "-" and "_" removed.
+var urlParamTypes = map[string]string{
+	// credentials and tokens
+	"token": "SECRET", "accesstoken": "SECRET", "refreshtoken": "SECRET", "idtoken": "SECRET",
+	"apikey": "SECRET", "key": "SECRET", "secret": "SECRET", "clientsecret": "SECRET",
+	"password": "SECRET", "passwd": "SECRET", "pwd": "SECRET", "pass": "SECRET",
+	"sig": "SECRET", "signature": "SECRET", "auth": "SECRET", "jwt": "SECRET", "code": "SECRET", "otp": "SECRET",
+	"session": "SECRET", "sessionid": "SECRET", "sid": "SECRET", "xamzsignature": "SECRET", "xamzcredential": "SECRET",
+	// contact details
+	"email": "EMAIL", "mail": "EMAIL", "emailaddress": "EMAIL",
+	"phone": "PHONE", "tel": "PHONE", "mobile": "PHONE", "msisdn": "PHONE", "telefon": "PHONE",
+	// names
+	"user": "PERSON", "username": "PERSON", "login": "PERSON", "name": "PERSON", "fullname": "PERSON",
+	"firstname": "PERSON", "lastname": "PERSON", "surname": "PERSON", "vorname": "PERSON", "nachname": "PERSON",
+	"customer": "PERSON", "patient": "PERSON",
+	// other identifiers
+	"iban": "IBAN", "dob": "DATE", "birthdate": "DATE", "geburtsdatum": "DATE",
+	"ssn": "SSN", "uid": "ID_NUMBER", "userid": "ID_NUMBER", "customerid": "ID_NUMBER", "patientid": "ID_NUMBER",
+}
+
+// urlPersonSegments are path segments after which the next segment names
+// or numbers a person: /users/thomas.schmidt, /patients/12345.
+var urlPersonSegments = map[string]bool{
+	"user": true, "users": true, "u": true, "profile": true, "profiles": true,
+	"people": true, "person": true, "member": true, "members": true,
+	"customer": true, "customers": true, "patient": true, "patients": true,
+	"employee": true, "employees": true, "account": true, "accounts": true,
+}
+
+// urlNonPersonSegments are segments after a person segment that address the
+// current user or a page rather than a person.
+var urlNonPersonSegments = map[string]bool{
+	"me": true, "self": true, "new": true, "edit": true, "settings": true, "search": true, "list": true, "login": true,
+}
+
+// parseURLs sets Parts on every URL entity that has sensitive components.
+func parseURLs(entities []Entity) []Entity {
+	for i, e := range entities {
+		if e.Type == "URL" {
+			entities[i].Parts = urlParts(e)
+		}
+	}
+	return entities
+}
+
+// urlParts returns the sensitive components of a URL as entities spanning
+// them in the full text: credentials in the userinfo (SECRET), path
+// segments that name or number a person or hold an email address, and
+// query and fragment values whose key or content reveals personal data or
+// a secret. The subtype tells where the component was found: url_userinfo,
+// url_path, url_query or url_fragment.
+func urlParts(e Entity) []Entity {
+	s := e.Text
+	scheme := strings.Index(s, "://")
+	if scheme < 0 {
+		return nil
+	}
+	authStart := scheme + 3
+	authEnd := len(s)
+	if i := strings.IndexAny(s[authStart:], "/?#"); i >= 0 {
+		authEnd = authStart + i
+	}
+	var parts []Entity
+	add := func(start, end int, typ, subtype string) {
+		if start < end {
+			parts = append(parts, Entity{
+				Start:    e.Start + start,
+				End:      e.Start + end,
+				Type:     typ,
+				Text:     s[start:end],
+				Score:    e.Score,
+				Detector: "url",
+				Subtype:  subtype,
+			})
+		}
+	}
+
+	if at := strings.LastIndexByte(s[authStart:authEnd], '@'); at >= 0 {
+		add(authStart, authStart+at, "SECRET", "url_userinfo")
+	}
+
+	pathEnd := len(s)
+	if i := strings.IndexAny(s[authEnd:], "?#"); i >= 0 {
+		pathEnd = authEnd + i
+	}
+	prev := ""
+	for start := authEnd; start < pathEnd; {
+		end := pathEnd
+		if i := strings.IndexByte(s[start+1:pathEnd], '/'); i >= 0 {
+			end = start + 1 + i
+		}
+		seg := s[start+1 : end]
+		if seg != "" {
+			decoded, err := url.PathUnescape(seg)
+			if err != nil {
+				decoded = seg
+			}
+			switch {
+			case urlEmailRe.MatchString(decoded):
+				add(start+1, end, "EMAIL", "url_path")
+			case strings.HasPrefix(seg, "~") && len(seg) > 1:
+				add(start+2, end, "PERSON", "url_path")
+			case urlPersonSegments[strings.ToLower(prev)] && !urlNonPersonSegments[strings.ToLower(decoded)]:
+				typ := "PERSON"
+				if strings.Trim(decoded, "0123456789") == "" {
+					typ = "ID_NUMBER"
+				}
+				add(start+1, end, typ, "url_path")
+			}
+		}
+		prev = seg
+		start = end
+	}
+
+	if pathEnd < len(s) && s[pathEnd] == '?' {
+		queryEnd := len(s)
+		if i := strings.IndexByte(s[pathEnd:], '#'); i >= 0 {
+			queryEnd = pathEnd + i
+		}
+		urlParams(s, pathEnd+1, queryEnd, "url_query", add)
+		pathEnd = queryEnd
+	}
+	if pathEnd < len(s) && s[pathEnd] == '#' {
+		frag := s[pathEnd+1:]
+		if decoded, err := url.QueryUnescape(frag); err == nil && urlEmailRe.MatchString(decoded) {
+			add(pathEnd+1, len(s), "EMAIL", "url_fragment")
+		} else if strings.Contains(frag, "=") {
+			urlParams(s, pathEnd+1, len(s), "url_fragment", add)
+		}
+	}
+	return parts
+}
+
+// urlParams calls add for the value of every sensitive key=value pair in
+// s[start:end].
+func urlParams(s string, start, end int, subtype string, add func(start, end int, typ, subtype string)) {
+	for pos := start; pos < end; {
+		pairEnd := end
+		if i := strings.IndexAny(s[pos:end], "&;"); i >= 0 {
+			pairEnd = pos + i
+		}
+		if eq := strings.IndexByte(s[pos:pairEnd], '='); eq >= 0 {
+			key, _ := url.QueryUnescape(s[pos : pos+eq])
+			valueStart := pos + eq + 1
+			value, err := url.QueryUnescape(s[valueStart:pairEnd])
+			if err != nil {
+				value = s[valueStart:pairEnd]
+			}
+			typ := urlParamTypes[strings.NewReplacer("-", "", "_", "").Replace(strings.ToLower(key))]
+			switch {
+			case urlEmailRe.MatchString(value):
+				typ = "EMAIL"
+			case typ == "" && strings.Contains(strings.ToLower(key), "token"):
+				typ = "SECRET"
+			}
+			if typ != "" {
+				add(valueStart, pairEnd, typ, subtype)
+			}
+		}
+		pos = pairEnd + 1
+	}
+}
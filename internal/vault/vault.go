@@ -0,0 +1,127 @@
+// Package vault keeps the originals of pseudonyms on the server, so that
+// clients can have text restored without holding its mapping table.
+package vault
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/svenplb/aegis-core/internal/redactor"
+)
+
+// Vault maps tokens to the mappings they were created from. It is safe for
+// concurrent use.
+type Vault struct {
+	mu      sync.RWMutex
+	entries map[string]redactor.Mapping
+	file    *os.File // nil for a vault kept in memory
+}
+
+// New returns an empty vault kept in memory.
+func New() *Vault {
+	return &Vault{entries: make(map[string]redactor.Mapping)}
+}
+
+// Open returns a vault persisted at path, one JSON mapping per line. The
+// file is created if it does not exist; new mappings are appended to it.
+func Open(path string) (*Vault, error) {
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("vault: open %s: %w", path, err)
+	}
+	v := New()
+	dec := json.NewDecoder(bufio.NewReader(f))
+	for {
+		var m redactor.Mapping
+		if err := dec.Decode(&m); errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			f.Close()
+			return nil, fmt.Errorf("vault: read %s: %w", path, err)
+		}
+		if _, ok := v.entries[m.Token]; !ok {
+			v.entries[m.Token] = m
+		}
+	}
+	v.file = f
+	return v, nil
+}
+
+// Put stores the mappings of pseudonyms whose token is not in the vault
+// yet. A token stands for the first value stored under it; later spellings
+// of the same value ("thomas schmidt", "Schmidt" for "Thomas Schmidt") do
+// not replace it. If a token already stands for a different value, two
+// values share a pseudonym: Put stores nothing and returns an error.
+func (v *Vault) Put(mappings []redactor.Mapping) error {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	for i, m := range mappings {
+		stored, ok := v.entries[m.Token]
+		if !ok {
+			// Also check the mappings stored by this call.
+			for _, prev := range mappings[:i] {
+				if prev.Token == m.Token {
+					stored, ok = prev, true
+					break
+				}
+			}
+		}
+		if ok && redactor.PseudonymValue(m.Type, value(m)) != redactor.PseudonymValue(stored.Type, value(stored)) {
+			return fmt.Errorf("vault: %s stands for two values; raise the pseudonym length", m.Token)
+		}
+	}
+	for _, m := range mappings {
+		if _, ok := v.entries[m.Token]; ok || m.Token == "" {
+			continue
+		}
+		m.Original = value(m)
+		m.Canonical = ""
+		if v.file != nil {
+			line, err := json.Marshal(m)
+			if err != nil {
+				return fmt.Errorf("vault: %w", err)
+			}
+			if _, err := v.file.Write(append(line, '\n')); err != nil {
+				return fmt.Errorf("vault: write: %w", err)
+			}
+		}
+		v.entries[m.Token] = m
+	}
+	return nil
+}
+
+// value returns the full value a mapping stands for.
+func value(m redactor.Mapping) string {
+	if m.Canonical != "" {
+		return m.Canonical
+	}
+	return m.Original
+}
+
+// Lookup returns the original of token.
+func (v *Vault) Lookup(token string) (string, bool) {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	m, ok := v.entries[token]
+	return m.Original, ok
+}
+
+// Len returns the number of tokens in the vault.
+func (v *Vault) Len() int {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	return len(v.entries)
+}
+
+// Close closes the vault's file.
+func (v *Vault) Close() error {
+	if v.file == nil {
+		return nil
+	}
+	return v.file.Close()
+}
@@ -0,0 +1,75 @@
+package vault
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/svenplb/aegis-core/internal/redactor"
+)
+
+func TestVault_PutLookup(t *testing.T) {
+	v := New()
+	err := v.Put([]redactor.Mapping{
+		{Token: "[PERSON_k1_7f3a9c]", Original: "Thomas Schmidt", Type: "PERSON"},
+		{Token: "[PERSON_k1_7f3a9c]", Original: "thomas schmidt", Type: "PERSON"},
+	})
+	if err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	if got, ok := v.Lookup("[PERSON_k1_7f3a9c]"); !ok || got != "Thomas Schmidt" {
+		t.Errorf("Lookup = %q, %v, want the first original", got, ok)
+	}
+	if _, ok := v.Lookup("[PERSON_k1_000000]"); ok {
+		t.Error("Lookup found an unknown token")
+	}
+}
+
+func TestVault_Conflict(t *testing.T) {
+	v := New()
+	ok := []redactor.Mapping{
+		{Token: "[PERSON_k1_7f3a9c]", Original: "Thomas Schmidt", Type: "PERSON"},
+		{Token: "[PERSON_k1_7f3a9c]", Original: "Schmidt", Type: "PERSON", Canonical: "Thomas Schmidt"},
+	}
+	if err := v.Put(ok); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	clash := []redactor.Mapping{
+		{Token: "[EMAIL_k1_a1b2c3]", Original: "anna@firma.de", Type: "EMAIL"},
+		{Token: "[PERSON_k1_7f3a9c]", Original: "Anna Weber", Type: "PERSON"},
+	}
+	if err := v.Put(clash); err == nil {
+		t.Fatal("Put accepted a second value for a token")
+	}
+	if got, _ := v.Lookup("[PERSON_k1_7f3a9c]"); got != "Thomas Schmidt" {
+		t.Errorf("Lookup = %q, want the first value", got)
+	}
+	if v.Len() != 1 {
+		t.Errorf("Len = %d, want 1: nothing of a conflicting call is stored", v.Len())
+	}
+}
+
+func TestVault_Persisted(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "vault.jsonl")
+	v, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	if err := v.Put([]redactor.Mapping{{Token: "[EMAIL_k1_a1b2c3]", Original: "anna@firma.de", Type: "EMAIL"}}); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	if err := v.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	v, err = Open(path)
+	if err != nil {
+		t.Fatalf("reopen: %v", err)
+	}
+	defer v.Close()
+	if got, ok := v.Lookup("[EMAIL_k1_a1b2c3]"); !ok || got != "anna@firma.de" {
+		t.Errorf("Lookup after reopen = %q, %v", got, ok)
+	}
+	if v.Len() != 1 {
+		t.Errorf("Len = %d, want 1", v.Len())
+	}
+}
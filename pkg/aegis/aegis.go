@@ -10,6 +10,7 @@ import (
 	"github.com/svenplb/aegis-core/internal/redactor"
 	"github.com/svenplb/aegis-core/internal/restorer"
 	"github.com/svenplb/aegis-core/internal/scanner"
+	"github.com/svenplb/aegis-core/internal/vault"
 )
 
 // ---------- Scanner types ----------
@@ -27,6 +28,26 @@ func DefaultScanner(allowlist []*regexp.Regexp) Scanner {
 	return scanner.DefaultScanner(allowlist)
 }
 
+// TranscriptScanner returns a scanner for speech-to-text transcripts: like
+// DefaultScanner, but numbers and dates spoken as words ("zero one seven
+// zero …", "the fifteenth of March") are detected as well, in English,
+// German, French, Spanish and Italian.
+func TranscriptScanner(allowlist []*regexp.Regexp) Scanner {
+	return scanner.DefaultScanner(allowlist).TranscriptMode()
+}
+
+// WithoutTestData returns a copy of a scanner created by DefaultScanner or
+// TranscriptScanner that drops well-known test values (4111 1111 1111 1111,
+// Max Mustermann) and values from documentation ranges (example.com,
+// 192.0.2.0/24) instead of flagging them with TestData. Other scanners are
+// returned unchanged.
+func WithoutTestData(s Scanner) Scanner {
+	if cs, ok := s.(*scanner.CompositeScanner); ok {
+		return cs.DropTestData()
+	}
+	return s
+}
+
 // NewCompositeScanner creates a scanner that merges results from multiple
 // child scanners, deduplicating overlapping spans.
 func NewCompositeScanner(scanners []Scanner, allowlist []*regexp.Regexp) Scanner {
@@ -38,6 +59,70 @@ func BuiltinScanners() []Scanner {
 	return scanner.BuiltinScanners()
 }
 
+// NewMRZScanner returns a scanner for passport and ID card machine-readable
+// zones. With decompose set, the holder name, birth date and document number
+// are emitted as separate entities instead of one ID_NUMBER block.
+func NewMRZScanner(decompose bool) Scanner {
+	return scanner.NewMRZScanner(decompose)
+}
+
+// GeneralizeLocation maps a city, region or country name to the English name
+// of its country, e.g. "München" → "Germany". It reports false for unknown names.
+func GeneralizeLocation(name string) (string, bool) {
+	return scanner.GeneralizeLocation(name)
+}
+
+// Normalize returns text in the form entity offsets refer to: NFC, with
+// no-break and other typographic spaces replaced by ASCII spaces.
+func Normalize(text string) string {
+	return scanner.Normalize(text)
+}
+
+// Cluster groups the entities that belong to one individual.
+type Cluster = scanner.Cluster
+
+// Clusters groups scan results by individual. Entities not linked to a
+// person are omitted.
+func Clusters(entities []Entity) []Cluster {
+	return scanner.Clusters(entities)
+}
+
+// Risk is the re-identification risk of a document: the combinations of
+// quasi-identifiers (birth date, age, postcode, city, gender, occupation)
+// that describe one person, each with a score and an explanation.
+type Risk = scanner.Risk
+
+// QuasiIdentifier is one value that helps to re-identify a person.
+type QuasiIdentifier = scanner.QuasiIdentifier
+
+// AssessRisk estimates how likely the person text is about can be
+// re-identified from the quasi-identifiers in it and the entities found by
+// a scanner, on a scale from 0 to 1.
+func AssessRisk(text string, entities []Entity) Risk {
+	return scanner.AssessRisk(text, entities)
+}
+
+// Report is the aggregated PII risk of one document: entity density,
+// highest sensitivity tier, secrets, special categories of data, distinct
+// individuals and a 0–100 score with its verdict.
+type Report = scanner.Report
+
+// ReportOption configures NewReport.
+type ReportOption = scanner.ReportOption
+
+// WithReportWeights overrides the weight of entity types in the report
+// score, e.g. {"SECRET": 40}. Unlisted types weigh 2, 5, 15 or 25 depending
+// on their sensitivity tier.
+func WithReportWeights(weights map[string]float64) ReportOption {
+	return scanner.WithReportWeights(weights)
+}
+
+// NewReport computes the risk report of a document from its text and the
+// entities a scanner found in it.
+func NewReport(text string, entities []Entity, opts ...ReportOption) Report {
+	return scanner.NewReport(text, entities, opts...)
+}
+
 // ---------- Redaction ----------
 
 // RedactResult holds the output of a Redact call.
@@ -46,11 +131,143 @@ type RedactResult = redactor.RedactResult
 // Mapping links a placeholder token to its original text.
 type Mapping = redactor.Mapping
 
+// RedactOption configures Redact.
+type RedactOption = redactor.Option
+
+// WithClusterTokens renders entities linked to a person relative to that
+// person's token, e.g. [PERSON_1_EMAIL] instead of [EMAIL_1].
+func WithClusterTokens(enabled bool) RedactOption {
+	return redactor.WithClusterTokens(enabled)
+}
+
+// RoleAction decides what happens to a PERSON entity with a given role.
+type RoleAction = redactor.Action
+
+// Actions for WithRolePolicy.
+const (
+	RoleRedact       = redactor.ActionRedact
+	RoleKeep         = redactor.ActionKeep
+	RolePseudonymize = redactor.ActionPseudonymize
+)
+
+// WithRolePolicy sets the action per person role ("patient", "clinician",
+// "legal_party", "family", "business", "title"), e.g. to keep treating
+// physicians while redacting patients. Unlisted roles are redacted.
+func WithRolePolicy(policy map[string]RoleAction) RedactOption {
+	return redactor.WithRolePolicy(policy)
+}
+
+// AmountMode decides how monetary amounts are redacted.
+type AmountMode = redactor.AmountMode
+
+// Modes for WithAmountGeneralization.
+const (
+	AmountToken     = redactor.AmountToken
+	AmountRange     = redactor.AmountRange
+	AmountMagnitude = redactor.AmountMagnitude
+)
+
+// WithAmountGeneralization replaces FINANCIAL amounts with the range they
+// fall in ([AMOUNT 1k–5k EUR]) or their order of magnitude ([AMOUNT ~1k
+// EUR]) instead of a [FINANCIAL_n] token. Generalized amounts are not
+// restored.
+func WithAmountGeneralization(mode AmountMode) RedactOption {
+	return redactor.WithAmountGeneralization(mode)
+}
+
+// AddressMode decides how addresses are redacted.
+type AddressMode = redactor.AddressMode
+
+// Modes for WithAddressGeneralization.
+const (
+	AddressToken   = redactor.AddressToken
+	AddressCity    = redactor.AddressCity
+	AddressCountry = redactor.AddressCountry
+)
+
+// WithAddressGeneralization replaces addresses with their city and country
+// ([ADDRESS Berlin, Germany]) or only their country ([ADDRESS Germany])
+// instead of an [ADDRESS_n] token. Generalized addresses are not restored.
+func WithAddressGeneralization(mode AddressMode) RedactOption {
+	return redactor.WithAddressGeneralization(mode)
+}
+
+// URLMode decides how URLs are redacted.
+type URLMode = redactor.URLMode
+
+// Modes for WithURLRedaction.
+const (
+	URLToken      = redactor.URLToken
+	URLComponents = redactor.URLComponents
+)
+
+// WithURLRedaction replaces only the sensitive components of URLs
+// (credentials, personal path segments, query and fragment values) and
+// keeps scheme, host and path, instead of replacing the whole URL with a
+// [URL_n] token.
+func WithURLRedaction(mode URLMode) RedactOption {
+	return redactor.WithURLRedaction(mode)
+}
+
+// WithRiskThreshold redacts the quasi-identifiers of every combination
+// whose re-identification risk (see AssessRisk) reaches threshold, including
+// gender and occupation words that are no entities of their own
+// ([QUASI_IDENTIFIER_1]). 0 disables it.
+func WithRiskThreshold(threshold float64) RedactOption {
+	return redactor.WithRiskThreshold(threshold)
+}
+
+// Strategy decides what replaces an entity in the sanitized text.
+// Implement it to plug in a strategy of your own.
+type Strategy = redactor.Strategy
+
+// Built-in strategies.
+type (
+	// TokenStrategy replaces an entity with its placeholder, [EMAIL_1].
+	TokenStrategy = redactor.TokenStrategy
+	// MaskStrategy replaces an entity with "***".
+	MaskStrategy = redactor.MaskStrategy
+	// PartialMaskStrategy keeps the last four digits of cards and account
+	// numbers, the first letters of names and the domain of emails.
+	PartialMaskStrategy = redactor.PartialMaskStrategy
+	// RemoveStrategy deletes an entity.
+	RemoveStrategy = redactor.RemoveStrategy
+	// LabelStrategy replaces every entity with a fixed label.
+	LabelStrategy = redactor.LabelStrategy
+	// TypeStrategy replaces an entity with its type, [EMAIL].
+	TypeStrategy = redactor.TypeStrategy
+)
+
+// WithStrategies sets the strategy per entity type, e.g.
+// {"CREDIT_CARD": PartialMaskStrategy{}}. Types without one get a
+// placeholder token. Only reversible strategies (tokens) are restored.
+func WithStrategies(byType map[string]Strategy) RedactOption {
+	return redactor.WithStrategies(byType)
+}
+
+// PseudonymStrategy replaces an entity with a keyed pseudonym that is the
+// same in every document, [PERSON_k1_7f3a9c0d5e21b84a].
+type PseudonymStrategy = redactor.PseudonymStrategy
+
+// NewPseudonymStrategy returns a PseudonymStrategy that pseudonymizes with
+// the key active; the pseudonyms of all keys remain Known, so that keys can
+// be rotated. length is the number of hex characters in a token (0 for 16).
+func NewPseudonymStrategy(active string, keys map[string][]byte, length int) (*PseudonymStrategy, error) {
+	return redactor.NewPseudonymStrategy(active, keys, length)
+}
+
+// ParseStrategies converts an entity type → strategy name map ("token",
+// "mask", "partial", "remove", "label", "type", or the name of a strategy
+// in extra, such as "pseudonym") into strategies for WithStrategies.
+func ParseStrategies(m map[string]string, extra ...Strategy) (map[string]Strategy, error) {
+	return redactor.ParseStrategies(m, extra...)
+}
+
 // Redact replaces every entity span in text with a placeholder token
 // (e.g. [PERSON_1]) and returns the sanitised text together with the
 // mapping table needed for restoration.
-func Redact(text string, entities []Entity) RedactResult {
-	return redactor.Redact(text, entities)
+func Redact(text string, entities []Entity, opts ...RedactOption) RedactResult {
+	return redactor.Redact(text, entities, opts...)
 }
 
 // ---------- Restoration ----------
@@ -61,6 +278,26 @@ func Restore(text string, mappings []Mapping) string {
 	return restorer.Restore(text, mappings)
 }
 
+// RestoreWith replaces every token in text for which lookup returns an
+// original, e.g. from a Vault.
+func RestoreWith(text string, lookup func(token string) (string, bool)) string {
+	return restorer.RestoreWith(text, lookup)
+}
+
+// Vault keeps the originals of pseudonyms, so that text can be restored
+// without shipping mapping tables.
+type Vault = vault.Vault
+
+// NewVault returns an empty vault kept in memory.
+func NewVault() *Vault {
+	return vault.New()
+}
+
+// OpenVault returns a vault persisted at path, one JSON mapping per line.
+func OpenVault(path string) (*Vault, error) {
+	return vault.Open(path)
+}
+
 // StreamRestorer incrementally restores tokens from streaming chunks,
 // buffering incomplete tokens (an opening '[' without a matching ']').
 type StreamRestorer = restorer.StreamRestorer